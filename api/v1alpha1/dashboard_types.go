@@ -28,6 +28,15 @@ const (
 
 var ERROR_STATUSES = []string{FAILING_STATUS, FLAKY_STATUS}
 
+const (
+	INFRA_CATEGORY    = "infra"
+	SETUP_CATEGORY    = "setup"
+	TEST_CATEGORY     = "test"
+	TEARDOWN_CATEGORY = "teardown"
+)
+
+var CATEGORIES = []string{INFRA_CATEGORY, SETUP_CATEGORY, TEST_CATEGORY, TEARDOWN_CATEGORY}
+
 // DashboardSpec defines the desired state of Dashboard.
 type DashboardSpec struct {
 	// DashboardTab is the name of the tab be scrapped from this board
@@ -42,6 +51,22 @@ type DashboardSpec struct {
 	// +kubebuilder:default=3
 	// MinFlake is the minimum number of flakes to consider a test group as flaky
 	MinFlakes int `json:"minFlakes,omitempty"`
+
+	// +kubebuilder:validation:Minimum=0
+	// Releases is the number of most recent release branches whose boards are discovered and scrapped
+	Releases int `json:"releases,omitempty"`
+
+	// +kubebuilder:default=sig-release
+	// DashboardGroup is the TestGrid dashboard group used to discover the release boards
+	DashboardGroup string `json:"dashboardGroup,omitempty"`
+
+	// +kubebuilder:validation:Minimum=0
+	// StaleMultiplier is the number of run intervals without a new run to consider a tab stale,
+	// 3 when unset and 0 to disable the check
+	StaleMultiplier *int `json:"staleMultiplier,omitempty"`
+
+	// IncludeStale also reports stale tabs whose overall status is PASSING
+	IncludeStale bool `json:"includeStale,omitempty"`
 }
 
 // DashboardStatus defines the observed state of a testgrid Dashboard.
@@ -73,6 +98,11 @@ type DashboardTab struct {
 	StateIcon string       `json:"icon"`
 	TabState  string       `json:"state"`
 	TestRuns  []TestResult `json:"tab_tests,omitempty"`
+
+	// Stale is set when the job has not run for several of its usual intervals
+	Stale bool `json:"stale,omitempty"`
+	// RunInterval is the usual time between two runs of the job in seconds
+	RunInterval int64 `json:"run_interval,omitempty"`
 }
 
 // TestResult contains details about an individual test run
@@ -83,6 +113,44 @@ type TestResult struct {
 	TriageURL       string `json:"triage_url"`
 	ProwJobURL      string `json:"prow_url"`
 	ErrorMessage    string `json:"error_message"`
+
+	// LastPassTimestamp is the timestamp of the most recent passing run, 0 if none is visible
+	LastPassTimestamp int64 `json:"last_pass_timestamp,omitempty"`
+	// FailureCount is the number of failing runs in the visible window
+	FailureCount int `json:"failure_count,omitempty"`
+	// FlakeCount is the number of runs that failed and passed on retry
+	FlakeCount int `json:"flake_count,omitempty"`
+	// CurrentStreak is the number of consecutive failures up to the most recent run
+	CurrentStreak int `json:"current_streak,omitempty"`
+	// FlipRate is the percentage of consecutive runs switching between pass and failure
+	FlipRate int `json:"flip_rate,omitempty"`
+	// FailureRatio is the percentage of runs that failed or flaked in the visible window
+	FailureRatio int `json:"failure_ratio,omitempty"`
+	// FlakinessScore is the recency-weighted flakiness of the test, from 0 to 100
+	FlakinessScore int `json:"flakiness_score,omitempty"`
+	// Sig is the SIG owning the test, without the sig- prefix
+	Sig string `json:"sig,omitempty"`
+	// LastPassCommit is the kubernetes commit of the most recent passing run
+	LastPassCommit string `json:"last_pass_commit,omitempty"`
+	// FirstFailureCommit is the kubernetes commit of the first failure after the last pass
+	FirstFailureCommit string `json:"first_failure_commit,omitempty"`
+	// +kubebuilder:validation:Enum=infra;setup;test;teardown
+	// Category tells whether the row is a job infrastructure, setup, test or teardown step
+	Category string `json:"category,omitempty"`
+	// JUnit is the failure read from the JUnit artifacts of the latest failing run
+	JUnit *JUnitResult `json:"junit,omitempty"`
+}
+
+// JUnitResult holds the failure of a test as reported in the JUnit artifacts
+type JUnitResult struct {
+	// FailureMessage is the complete failure output of the test
+	FailureMessage string `json:"failure_message,omitempty"`
+	// Location is the source location of the failure
+	Location string `json:"location,omitempty"`
+	// Duration is the time the test ran for
+	Duration metav1.Duration `json:"duration,omitempty"`
+	// SystemOut is the output captured while the test ran
+	SystemOut string `json:"system_out,omitempty"`
 }
 
 // +kubebuilder:object:root=true
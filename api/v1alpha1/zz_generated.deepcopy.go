@@ -29,7 +29,7 @@ func (in *Dashboard) DeepCopyInto(out *Dashboard) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	out.Spec = in.Spec
+	in.Spec.DeepCopyInto(&out.Spec)
 	in.Status.DeepCopyInto(&out.Status)
 }
 
@@ -86,6 +86,11 @@ func (in *DashboardList) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DashboardSpec) DeepCopyInto(out *DashboardSpec) {
 	*out = *in
+	if in.StaleMultiplier != nil {
+		in, out := &in.StaleMultiplier, &out.StaleMultiplier
+		*out = new(int)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DashboardSpec.
@@ -147,7 +152,9 @@ func (in *DashboardTab) DeepCopyInto(out *DashboardTab) {
 	if in.TestRuns != nil {
 		in, out := &in.TestRuns, &out.TestRuns
 		*out = make([]TestResult, len(*in))
-		copy(*out, *in)
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 }
 
@@ -161,9 +168,30 @@ func (in *DashboardTab) DeepCopy() *DashboardTab {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JUnitResult) DeepCopyInto(out *JUnitResult) {
+	*out = *in
+	out.Duration = in.Duration
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JUnitResult.
+func (in *JUnitResult) DeepCopy() *JUnitResult {
+	if in == nil {
+		return nil
+	}
+	out := new(JUnitResult)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TestResult) DeepCopyInto(out *TestResult) {
 	*out = *in
+	if in.JUnit != nil {
+		in, out := &in.JUnit, &out.JUnit
+		*out = new(JUnitResult)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TestResult.
@@ -4,13 +4,18 @@ package cmd
 
 import (
 	"fmt"
+	"net/http"
 	"os"
 	"time"
 
 	"github.com/spf13/cobra"
 
 	"sigs.k8s.io/signalhound/api/v1alpha1"
-	"sigs.k8s.io/signalhound/internal/testgrid"
+	"sigs.k8s.io/signalhound/internal/cache"
+	"sigs.k8s.io/signalhound/internal/config"
+	"sigs.k8s.io/signalhound/internal/github"
+	"sigs.k8s.io/signalhound/internal/jobconfig"
+	"sigs.k8s.io/signalhound/internal/snapshot"
 	"sigs.k8s.io/signalhound/internal/tui"
 )
 
@@ -22,21 +27,31 @@ var abstractCmd = &cobra.Command{
 }
 
 var (
-	tg                   = testgrid.NewTestGrid(testgrid.URL)
-	minFailure, minFlake int
-	refreshInterval      int
-	token                string
+	refreshInterval int
+	fromSnapshot    string
+	token           string
+	jobConfigDir    string
+	issueOptions    github.IssueOptions
 )
 
 func init() {
 	rootCmd.AddCommand(abstractCmd)
 
-	abstractCmd.PersistentFlags().IntVarP(&minFailure, "min-failure", "f", 0,
-		"minimum threshold for test failures, to disable use 0. Defaults to 0.")
-	abstractCmd.PersistentFlags().IntVarP(&minFlake, "min-flake", "m", 0,
-		"minimum threshold for test flakeness, to disable use 0. Defaults to 0.")
+	addFetchFlags(abstractCmd)
 	abstractCmd.PersistentFlags().IntVarP(&refreshInterval, "refresh-interval", "r", 0,
 		"refresh interval in seconds (0 to disable auto-refresh)")
+	abstractCmd.PersistentFlags().StringVar(&fromSnapshot, "from-snapshot", "",
+		"render the TUI from a snapshot file instead of fetching TestGrid")
+	abstractCmd.PersistentFlags().StringVar(&jobConfigDir, "job-config-dir", "",
+		"test-infra Prow job config checkout, e.g. test-infra/config/jobs, showing the owners, alert emails, "+
+			"interval and timeout of the jobs")
+	abstractCmd.PersistentFlags().StringVar(&issueOptions.Repository, "issue-repository", "",
+		"owner/name of the repository of the issues filed with ctrl-n (default the --github-repository)")
+	abstractCmd.PersistentFlags().StringVar(&issueOptions.Milestone, "milestone", "",
+		"open milestone of the issues filed with ctrl-n, e.g. v1.35")
+	abstractCmd.PersistentFlags().StringSliceVar(&issueOptions.Labels, "issue-labels", nil,
+		"labels added to the kind and sig labels of the issues filed with ctrl-n")
+	addHistoryFlags(abstractCmd)
 
 	token = os.Getenv("SIGNALHOUND_GITHUB_TOKEN")
 	if token == "" {
@@ -44,41 +59,82 @@ func init() {
 	}
 }
 
-// FetchTabSummary fetches all dashboard tabs from TestGrid.
-func FetchTabSummary() ([]*v1alpha1.DashboardTab, error) {
-	var dashboardTabs []*v1alpha1.DashboardTab
-	for _, dashboard := range []string{"sig-release-master-blocking", "sig-release-master-informing"} {
-		dashSummaries, err := tg.FetchTabSummary(dashboard, v1alpha1.ERROR_STATUSES)
+// RunAbstract starts the main command to scrape TestGrid.
+func RunAbstract(cmd *cobra.Command, args []string) error {
+	cfg, err := config.Load(configFile)
+	if err != nil {
+		return err
+	}
+	linkConfig, err := resolveLinks(cfg)
+	if err != nil {
+		return err
+	}
+	project, err := resolveProject(cfg)
+	if err != nil {
+		return err
+	}
+	jobs, err := loadJobConfig()
+	if err != nil {
+		return err
+	}
+	transport := newTransport()
+	tuiOptions := tui.Options{
+		Token:      token,
+		Links:      linkConfig,
+		Jobs:       jobs,
+		Project:    project,
+		Issues:     issueOptions,
+		HTTPClient: &http.Client{Transport: transport},
+	}
+	if fromSnapshot != "" {
+		snap, err := snapshot.Load(fromSnapshot)
 		if err != nil {
-			return nil, err
-		}
-		for _, dashSummary := range dashSummaries {
-			dashTab, err := tg.FetchTabTests(&dashSummary, minFailure, minFlake)
-			if err != nil {
-				fmt.Println(fmt.Errorf("error fetching table : %s", err))
-				continue
-			}
-			if len(dashTab.TestRuns) > 0 {
-				dashboardTabs = append(dashboardTabs, dashTab)
-			}
+			return err
 		}
+		return tui.RenderVisual(snap.Tabs, tuiOptions)
 	}
-	return dashboardTabs, nil
-}
 
-// RunAbstract starts the main command to scrape TestGrid.
-func RunAbstract(cmd *cobra.Command, args []string) error {
-	dashboardTabs, err := FetchTabSummary()
+	ctx := cmd.Context()
+	dashboards, err := setupTestGrid(ctx, transport)
+	if err != nil {
+		return err
+	}
+
+	dashboardTabs, err := fetchWithProgress(ctx, dashboards)
 	if err != nil {
 		return err
 	}
+	if err := recordHistory(dashboards, dashboardTabs); err != nil {
+		fmt.Fprintf(os.Stderr, "error recording history: %v\n", err)
+	}
 
-	var refreshFunc func() ([]*v1alpha1.DashboardTab, error)
 	if refreshInterval > 0 {
-		refreshFunc = func() ([]*v1alpha1.DashboardTab, error) {
-			return FetchTabSummary()
+		tuiOptions.RefreshInterval = time.Duration(refreshInterval) * time.Second
+		tuiOptions.Refresh = func() ([]*v1alpha1.DashboardTab, error) {
+			// revalidate the cached responses, fresh ones would hide the new runs until the TTL expires
+			dashboardTabs, _, err := FetchTabSummary(cache.WithRevalidate(ctx), dashboards, nil)
+			if err == nil {
+				// the TUI owns the terminal, a failed history write must not hide the refresh
+				_ = recordHistory(dashboards, dashboardTabs)
+			}
+			return dashboardTabs, err
 		}
 	}
 
-	return tui.RenderVisual(dashboardTabs, token, time.Duration(refreshInterval)*time.Second, refreshFunc)
+	return tui.RenderVisual(dashboardTabs, tuiOptions)
+}
+
+// loadJobConfig indexes the Prow jobs of --job-config-dir, nil when unset.
+func loadJobConfig() (*jobconfig.Index, error) {
+	if jobConfigDir == "" {
+		return nil, nil
+	}
+	jobs, err := jobconfig.Load(jobConfigDir)
+	if err != nil {
+		return nil, fmt.Errorf("error loading the job config: %w", err)
+	}
+	if len(jobs.Skipped) > 0 {
+		fmt.Fprintf(os.Stderr, "skipped %d job config files that could not be parsed\n", len(jobs.Skipped))
+	}
+	return jobs, nil
 }
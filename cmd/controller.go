@@ -37,6 +37,7 @@ var (
 	probeAddr                                        string
 	secureMetrics                                    bool
 	enableHTTP2                                      bool
+	jobHistoryBuilds                                 int
 )
 
 // controllerCmd represents the controller command
@@ -77,6 +78,10 @@ func init() {
 		"The name of the metrics server key file.")
 	controllerCmd.PersistentFlags().BoolVar(&enableHTTP2, "enable-http2", false,
 		"If set, HTTP/2 will be enabled for the metrics and webhook servers")
+	controllerCmd.PersistentFlags().IntVar(&jobHistoryBuilds, "job-history-builds", 10,
+		"The number of latest builds read from the Prow job history of failing and flaky tabs "+
+			"for the job duration metric, 0 disables it.")
+	addHistoryFlags(controllerCmd)
 }
 
 // nolint:gocyclo
@@ -171,9 +176,18 @@ func RunController(cmd *cobra.Command, args []string) error {
 		os.Exit(1)
 	}
 
+	linkConfig, err := loadLinks()
+	if err != nil {
+		setupLog.Error(err, "unable to load the links configuration")
+		os.Exit(1)
+	}
+
 	if err = (&controller.DashboardReconciler{
-		Client: mgr.GetClient(),
-		Scheme: mgr.GetScheme(),
+		Client:           mgr.GetClient(),
+		Scheme:           mgr.GetScheme(),
+		History:          newHistoryStore(),
+		JobHistoryBuilds: jobHistoryBuilds,
+		Links:            linkConfig,
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "Dashboard")
 		os.Exit(1)
@@ -0,0 +1,117 @@
+/* Copyright 2025 Amim Knabben */
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"sigs.k8s.io/signalhound/internal/diff"
+	"sigs.k8s.io/signalhound/internal/snapshot"
+)
+
+const (
+	liveSource    = "live"
+	historySource = "history:"
+)
+
+// diffCmd represents the diff command
+var diffCmd = &cobra.Command{
+	Use:   "diff",
+	Short: "Show the new, persisting, escalated and resolved failures between two board states",
+	Long: `Compare two board states, each one is either:
+  live                  fetch TestGrid now
+  history:latest        the most recent entry of the history store
+  history:24h           the history entry from 24 hours ago
+  history:<RFC3339>     the history entry at or before the given time
+  <file>                a snapshot file written by snapshot save`,
+	RunE: RunDiff,
+}
+
+var (
+	diffBefore string
+	diffAfter  string
+	diffOutput string
+)
+
+func init() {
+	rootCmd.AddCommand(diffCmd)
+
+	addFetchFlags(diffCmd)
+	addHistoryFlags(diffCmd)
+	diffCmd.PersistentFlags().StringVar(&diffBefore, "before", historySource+"latest",
+		"board state to compare from")
+	diffCmd.PersistentFlags().StringVar(&diffAfter, "after", liveSource,
+		"board state to compare to")
+	diffCmd.PersistentFlags().StringVarP(&diffOutput, "output", "o", diff.FormatText,
+		fmt.Sprintf("output format, one of %v", diff.Formats))
+}
+
+// RunDiff loads both board states and prints their differences.
+func RunDiff(cmd *cobra.Command, args []string) error {
+	before, err := loadTabs(cmd.Context(), diffBefore)
+	if err != nil {
+		return fmt.Errorf("error loading %s: %w", diffBefore, err)
+	}
+	after, err := loadTabs(cmd.Context(), diffAfter)
+	if err != nil {
+		return fmt.Errorf("error loading %s: %w", diffAfter, err)
+	}
+	return diff.Write(os.Stdout, diff.Compare(before, after), diffOutput)
+}
+
+// loadTabs returns the scraped dashboards and the tabs of a live fetch, a
+// history entry or a snapshot file.
+func loadTabs(ctx context.Context, source string) (diff.Side, error) {
+	switch {
+	case source == liveSource:
+		dashboards, err := setupTestGrid(ctx, newTransport())
+		if err != nil {
+			return diff.Side{}, err
+		}
+		tabs, err := fetchWithProgress(ctx, dashboards)
+		if err != nil {
+			return diff.Side{}, err
+		}
+		return diff.Side{Dashboards: dashboardNamesOf(dashboards), Tabs: tabs}, nil
+	case strings.HasPrefix(source, historySource):
+		store := newHistoryStore()
+		if store == nil {
+			return diff.Side{}, fmt.Errorf("the history store is not configured, set --history-dir")
+		}
+		at, err := parseHistoryTime(strings.TrimPrefix(source, historySource))
+		if err != nil {
+			return diff.Side{}, err
+		}
+		entry, err := store.At(at)
+		if err != nil {
+			return diff.Side{}, err
+		}
+		return diff.Side{Dashboards: entry.Dashboards, Tabs: entry.Tabs}, nil
+	}
+	snap, err := snapshot.Load(source)
+	if err != nil {
+		return diff.Side{}, err
+	}
+	return diff.Side{Dashboards: snap.Dashboards, Tabs: snap.Tabs}, nil
+}
+
+// parseHistoryTime reads latest, a duration ago or an RFC3339 time.
+func parseHistoryTime(value string) (time.Time, error) {
+	if value == "latest" {
+		return time.Now(), nil
+	}
+	if ago, err := time.ParseDuration(value); err == nil {
+		return time.Now().Add(-ago), nil
+	}
+	at, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid history time %q, use latest, a duration or an RFC3339 time", value)
+	}
+	return at, nil
+}
@@ -0,0 +1,192 @@
+/* Copyright 2025 Amim Knabben */
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"slices"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"sigs.k8s.io/signalhound/api/v1alpha1"
+	"sigs.k8s.io/signalhound/internal/cache"
+	"sigs.k8s.io/signalhound/internal/config"
+	"sigs.k8s.io/signalhound/internal/sig"
+	"sigs.k8s.io/signalhound/internal/testgrid"
+)
+
+var (
+	tg                   *testgrid.TestGrid
+	minFailure, minFlake int
+	minFlakeScore        int
+	dashboardNames       []string
+	concurrency          int
+	requestTimeout       time.Duration
+	cacheTTL             time.Duration
+	noCache              bool
+	sigMappingFile       string
+	releases             int
+	dashboardGroup       string
+	staleMultiplier      int
+	includeStale         bool
+	categories           []string
+)
+
+// addFetchFlags registers the flags controlling how TestGrid is scraped.
+func addFetchFlags(cmd *cobra.Command) {
+	cmd.PersistentFlags().IntVarP(&minFailure, "min-failure", "f", 0,
+		"minimum threshold for test failures, to disable use 0. Defaults to 0.")
+	cmd.PersistentFlags().IntVarP(&minFlake, "min-flake", "m", 0,
+		"minimum threshold for test flakeness, to disable use 0. Defaults to 0.")
+	cmd.PersistentFlags().IntVar(&minFlakeScore, "min-flake-score", 0,
+		"minimum flakiness score from 0 to 100 for tests on flaky tabs, to disable use 0.")
+	cmd.PersistentFlags().StringArrayVarP(&dashboardNames, "dashboard", "d", nil,
+		"TestGrid dashboard to summarize, can be repeated. Defaults to the config file list or the master blocking and informing boards.")
+	cmd.PersistentFlags().IntVar(&releases, "releases", 0,
+		"also summarize the blocking and informing boards of the N most recent release branches, discovered from TestGrid")
+	cmd.PersistentFlags().StringVar(&dashboardGroup, "dashboard-group", testgrid.DefaultDashboardGroup,
+		"TestGrid dashboard group used to discover the release branch boards")
+	cmd.PersistentFlags().IntVar(&staleMultiplier, "stale-multiplier", testgrid.DefaultStaleMultiplier,
+		"number of usual run intervals without a new run to flag a tab as stale, to disable use 0")
+	cmd.PersistentFlags().BoolVar(&includeStale, "include-stale", false,
+		"also show stale tabs whose overall status is PASSING")
+	cmd.PersistentFlags().IntVar(&concurrency, "concurrency", testgrid.DefaultConcurrency,
+		"number of TestGrid tabs fetched in parallel")
+	cmd.PersistentFlags().DurationVar(&requestTimeout, "request-timeout", 30*time.Second,
+		"timeout of a single TestGrid request, to disable use 0")
+	cmd.PersistentFlags().DurationVar(&cacheTTL, "cache-ttl", 5*time.Minute,
+		"time a cached TestGrid or Prow response is used without revalidation, to always revalidate use 0. "+
+			"The --refresh-interval reloads always revalidate")
+	cmd.PersistentFlags().BoolVar(&noCache, "no-cache", false,
+		"disable the on-disk response cache")
+	cmd.PersistentFlags().StringVar(&sigMappingFile, "sig-mapping", "",
+		"file mapping job and test name regular expressions to SIGs, used when the test name has no [sig-*] tag")
+	cmd.PersistentFlags().StringSliceVar(&categories, "category", nil,
+		"only show tests of these categories: infra, setup, test or teardown (default all)")
+}
+
+// setupTestGrid loads the config file, creates the TestGrid client on top of
+// the transport and returns the dashboards to be scraped, including the
+// discovered release branch boards when requested.
+func setupTestGrid(ctx context.Context, transport http.RoundTripper) ([]config.Dashboard, error) {
+	for _, category := range categories {
+		if !slices.Contains(v1alpha1.CATEGORIES, category) {
+			return nil, fmt.Errorf("unknown category %q, valid categories are %v", category, v1alpha1.CATEGORIES)
+		}
+	}
+
+	cfg, err := config.Load(configFile)
+	if err != nil {
+		return nil, err
+	}
+	linkConfig, err := resolveLinks(cfg)
+	if err != nil {
+		return nil, err
+	}
+	opts := []testgrid.Option{
+		testgrid.WithLinks(linkConfig),
+		testgrid.WithTimeout(requestTimeout),
+		testgrid.WithHTTPClient(&http.Client{Transport: transport}),
+		testgrid.WithStaleMultiplier(staleMultiplier),
+	}
+	if sigMappingFile != "" {
+		mapping, err := sig.LoadMapping(sigMappingFile)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, testgrid.WithSigMapping(mapping))
+	}
+	tg = testgrid.NewTestGrid(linkConfig.TestGridURL, opts...)
+
+	// copy the resolved list, it may be the config file or the flag one
+	dashboards := slices.Clone(cfg.ResolveDashboards(dashboardNames))
+	if releases > 0 {
+		discovered, err := tg.DiscoverReleaseDashboards(ctx, dashboardGroup, releases)
+		if err != nil {
+			return nil, err
+		}
+		for _, name := range discovered {
+			if !slices.ContainsFunc(dashboards, func(dashboard config.Dashboard) bool { return dashboard.Name == name }) {
+				// keep the thresholds of a discovered board listed in the config file
+				dashboards = append(dashboards, cfg.ResolveDashboards([]string{name})...)
+			}
+		}
+	}
+	return dashboards, nil
+}
+
+// dashboardNamesOf returns the names of the dashboards.
+func dashboardNamesOf(dashboards []config.Dashboard) []string {
+	names := make([]string, 0, len(dashboards))
+	for _, dashboard := range dashboards {
+		names = append(names, dashboard.Name)
+	}
+	return names
+}
+
+// FetchTabSummary fetches all dashboard tabs from TestGrid. Tabs that could not
+// be fetched are returned in the error list alongside the partial result.
+func FetchTabSummary(ctx context.Context, dashboards []config.Dashboard, progress testgrid.ProgressFunc) ([]*v1alpha1.DashboardTab, []testgrid.TabError, error) {
+	statuses := v1alpha1.ERROR_STATUSES
+	if includeStale {
+		statuses = append([]string{v1alpha1.PASSING_STATUS}, statuses...)
+	}
+
+	var requests []testgrid.TabRequest
+	for _, dashboard := range dashboards {
+		dashMinFailure, dashMinFlake := dashboard.Thresholds(minFailure, minFlake)
+		dashSummaries, err := tg.FetchTabSummary(ctx, dashboard.Name, statuses)
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, dashSummary := range dashSummaries {
+			requests = append(requests, testgrid.TabRequest{
+				Summary:    dashSummary,
+				MinFailure: dashMinFailure,
+				MinFlake:   dashMinFlake,
+			})
+		}
+	}
+
+	tabs, tabErrors := tg.FetchTabsTests(ctx, requests, concurrency, progress)
+	var dashboardTabs []*v1alpha1.DashboardTab
+	for _, dashTab := range tabs {
+		testgrid.FilterByFlakinessScore(dashTab, minFlakeScore)
+		testgrid.FilterByCategory(dashTab, categories)
+		if len(dashTab.TestRuns) > 0 || dashTab.Stale {
+			dashboardTabs = append(dashboardTabs, dashTab)
+		}
+	}
+	return dashboardTabs, tabErrors, nil
+}
+
+// fetchWithProgress fetches the dashboards printing the progress and the
+// tabs that could not be fetched on stderr.
+func fetchWithProgress(ctx context.Context, dashboards []config.Dashboard) ([]*v1alpha1.DashboardTab, error) {
+	dashboardTabs, tabErrors, err := FetchTabSummary(ctx, dashboards, printProgress)
+	fmt.Fprintln(os.Stderr)
+	if err != nil {
+		return nil, err
+	}
+	for _, tabError := range tabErrors {
+		fmt.Fprintln(os.Stderr, tabError.Error())
+	}
+	return dashboardTabs, nil
+}
+
+// printProgress reports the number of fetched tabs before the TUI takes the terminal.
+func printProgress(done, total int) {
+	fmt.Fprintf(os.Stderr, "\rFetching TestGrid tabs %d/%d", done, total)
+}
+
+// newTransport returns the HTTP transport shared by TestGrid and Prow requests.
+func newTransport() http.RoundTripper {
+	if noCache {
+		return http.DefaultTransport
+	}
+	return &cache.Transport{Cache: cache.New(cache.DefaultDir(), cacheTTL), Base: http.DefaultTransport}
+}
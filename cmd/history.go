@@ -0,0 +1,96 @@
+/* Copyright 2025 Amim Knabben */
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"sigs.k8s.io/signalhound/api/v1alpha1"
+	"sigs.k8s.io/signalhound/internal/config"
+	"sigs.k8s.io/signalhound/internal/history"
+)
+
+// historyCmd represents the history command
+var historyCmd = &cobra.Command{
+	Use:   "history <test name>",
+	Short: "Show how a test behaved across the stored board history",
+	Args:  cobra.ExactArgs(1),
+	RunE:  RunHistory,
+}
+
+var (
+	historyDir       string
+	historyRetention time.Duration
+	historySince     time.Duration
+	historyBoard     string
+)
+
+func init() {
+	rootCmd.AddCommand(historyCmd)
+
+	addHistoryFlags(historyCmd)
+	historyCmd.PersistentFlags().DurationVar(&historySince, "since", history.DefaultRetention,
+		"how far back to look in the history")
+	historyCmd.PersistentFlags().StringVar(&historyBoard, "board", "",
+		"only show the history of this Board#Tab")
+}
+
+// addHistoryFlags registers the history store location and retention.
+func addHistoryFlags(cmd *cobra.Command) {
+	cmd.PersistentFlags().StringVar(&historyDir, "history-dir", "",
+		"directory of the history store, every fetch is appended to it when set")
+	cmd.PersistentFlags().DurationVar(&historyRetention, "history-retention", history.DefaultRetention,
+		"how long history entries are kept, to keep everything use 0")
+}
+
+// newHistoryStore returns the configured store, nil when the history is disabled.
+func newHistoryStore() *history.Store {
+	if historyDir == "" {
+		return nil
+	}
+	return history.New(historyDir, historyRetention)
+}
+
+// recordHistory appends the tabs of the dashboards to the history store when enabled.
+func recordHistory(dashboards []config.Dashboard, tabs []*v1alpha1.DashboardTab) error {
+	store := newHistoryStore()
+	if store == nil {
+		return nil
+	}
+	return store.Append(time.Now(), dashboardNamesOf(dashboards), tabs)
+}
+
+// RunHistory prints the stored records of a test.
+func RunHistory(cmd *cobra.Command, args []string) error {
+	store := newHistoryStore()
+	if store == nil {
+		return fmt.Errorf("the history store is not configured, set --history-dir")
+	}
+
+	records, err := store.TestHistory(historyBoard, args[0], time.Now().Add(-historySince))
+	if err != nil {
+		return err
+	}
+	if len(records) == 0 {
+		fmt.Printf("no record of %q in the last %s\n", args[0], historySince)
+		return nil
+	}
+
+	writer := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(writer, "TIME\tBOARD#TAB\tSTATE\tFAILURES\tFLAKES\tSCORE") // nolint: errcheck
+	for _, record := range records {
+		fmt.Fprintf(writer, "%s\t%s\t%s\t%d\t%d\t%d\n", // nolint: errcheck
+			record.Time.Local().Format(time.DateTime), record.BoardHash, record.TabState,
+			record.Result.FailureCount, record.Result.FlakeCount, record.Result.FlakinessScore)
+	}
+	if err := writer.Flush(); err != nil {
+		return err
+	}
+	fmt.Printf("first seen %s ago\n", time.Since(records[0].Time).Round(time.Minute))
+	return nil
+}
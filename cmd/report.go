@@ -0,0 +1,55 @@
+/* Copyright 2025 Amim Knabben */
+
+package cmd
+
+import (
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"sigs.k8s.io/signalhound/api/v1alpha1"
+	"sigs.k8s.io/signalhound/internal/cluster"
+	"sigs.k8s.io/signalhound/internal/report"
+	"sigs.k8s.io/signalhound/internal/snapshot"
+)
+
+// reportCmd represents the report command
+var reportCmd = &cobra.Command{
+	Use:   "report",
+	Short: "Print the flake or failing tests and their failure clusters without the TUI",
+	RunE:  RunReport,
+}
+
+var clusterThreshold float64
+
+func init() {
+	rootCmd.AddCommand(reportCmd)
+
+	addFetchFlags(reportCmd)
+	reportCmd.PersistentFlags().StringVar(&fromSnapshot, "from-snapshot", "",
+		"report from a snapshot file instead of fetching TestGrid")
+	reportCmd.PersistentFlags().Float64Var(&clusterThreshold, "cluster-threshold", cluster.DefaultThreshold,
+		"similarity from 0 to 1 above which failure messages are clustered together")
+}
+
+// RunReport fetches the dashboards and prints the report on stdout.
+func RunReport(cmd *cobra.Command, args []string) error {
+	var dashboardTabs []*v1alpha1.DashboardTab
+	if fromSnapshot != "" {
+		snap, err := snapshot.Load(fromSnapshot)
+		if err != nil {
+			return err
+		}
+		dashboardTabs = snap.Tabs
+	} else {
+		dashboards, err := setupTestGrid(cmd.Context(), newTransport())
+		if err != nil {
+			return err
+		}
+		if dashboardTabs, err = fetchWithProgress(cmd.Context(), dashboards); err != nil {
+			return err
+		}
+	}
+
+	return report.Write(os.Stdout, dashboardTabs, cluster.Build(dashboardTabs, clusterThreshold))
+}
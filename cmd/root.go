@@ -1,9 +1,15 @@
 package cmd
 
 import (
+	"fmt"
 	"os"
+	"strconv"
 
 	"github.com/spf13/cobra"
+
+	"sigs.k8s.io/signalhound/internal/config"
+	"sigs.k8s.io/signalhound/internal/github"
+	"sigs.k8s.io/signalhound/internal/links"
 )
 
 var (
@@ -12,8 +18,72 @@ var (
 		Short: "signalhound search for issues and flaky tests on Kubernetes",
 		Long:  "signalhound search for issues and flaky tests on Kubernetes",
 	}
+	configFile   string
+	linkFlags    links.Config
+	projectFlags github.ProjectConfig
 )
 
+func init() {
+	rootCmd.PersistentFlags().StringVar(&configFile, "config", config.DefaultPath(),
+		"path to the signalhound config file")
+	rootCmd.PersistentFlags().StringVar(&linkFlags.TestGridURL, "testgrid-url", "",
+		"TestGrid instance to scrape and link to (default "+links.DefaultTestGridURL+")")
+	rootCmd.PersistentFlags().StringVar(&linkFlags.ProwURL, "prow-url", "",
+		"Prow instance serving the job pages (default "+links.DefaultProwURL+")")
+	rootCmd.PersistentFlags().StringVar(&linkFlags.ArtifactURL, "artifact-url", "",
+		"public endpoint of the bucket storing the job artifacts (default "+links.DefaultArtifactURL+")")
+	rootCmd.PersistentFlags().StringVar(&linkFlags.TriageURL, "triage-url", "",
+		"triage dashboard linked from the tests (default "+links.DefaultTriageURL+")")
+	rootCmd.PersistentFlags().StringVar(&linkFlags.Storage, "prow-storage", "",
+		"storage provider of the Prow job pages, e.g. gs or s3 (default "+links.DefaultStorage+")")
+	rootCmd.PersistentFlags().StringVar(&projectFlags.Organization, "github-org", "",
+		"GitHub organization of the project, also read from SIGNALHOUND_GITHUB_ORG (default "+github.DefaultOrganization+")")
+	rootCmd.PersistentFlags().IntVar(&projectFlags.ProjectNumber, "github-project-number", 0,
+		"number of the GitHub project of the drafts, also read from SIGNALHOUND_GITHUB_PROJECT_NUMBER "+
+			"(default the CI signal project)")
+	rootCmd.PersistentFlags().StringVar(&projectFlags.Repository, "github-repository", "",
+		"owner/name of the code repository of the pull requests and issues, also read from SIGNALHOUND_GITHUB_REPOSITORY "+
+			"(default "+github.DefaultRepository+")")
+}
+
+// resolveLinks returns the links of the config file overridden by the flags.
+func resolveLinks(cfg *config.Config) (links.Config, error) {
+	resolved := cfg.Links.Override(linkFlags).WithDefaults()
+	if err := resolved.Validate(); err != nil {
+		return links.Config{}, err
+	}
+	return resolved, nil
+}
+
+// loadLinks reads the config file and resolves the links.
+func loadLinks() (links.Config, error) {
+	cfg, err := config.Load(configFile)
+	if err != nil {
+		return links.Config{}, err
+	}
+	return resolveLinks(cfg)
+}
+
+// resolveProject returns the GitHub project of the config file overridden by
+// the environment, then by the flags.
+func resolveProject(cfg *config.Config) (github.ProjectConfig, error) {
+	var env github.ProjectConfig
+	env.Organization = os.Getenv("SIGNALHOUND_GITHUB_ORG")
+	env.Repository = os.Getenv("SIGNALHOUND_GITHUB_REPOSITORY")
+	if number := os.Getenv("SIGNALHOUND_GITHUB_PROJECT_NUMBER"); number != "" {
+		projectNumber, err := strconv.Atoi(number)
+		if err != nil {
+			return github.ProjectConfig{}, fmt.Errorf("invalid SIGNALHOUND_GITHUB_PROJECT_NUMBER %q: %w", number, err)
+		}
+		env.ProjectNumber = projectNumber
+	}
+	resolved := cfg.GitHub.Override(env).Override(projectFlags).WithDefaults()
+	if err := resolved.Validate(); err != nil {
+		return github.ProjectConfig{}, err
+	}
+	return resolved, nil
+}
+
 func Execute() {
 	err := rootCmd.Execute()
 	if err != nil {
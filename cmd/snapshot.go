@@ -0,0 +1,52 @@
+/* Copyright 2025 Amim Knabben */
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"sigs.k8s.io/signalhound/internal/snapshot"
+)
+
+// snapshotCmd represents the snapshot command
+var snapshotCmd = &cobra.Command{
+	Use:   "snapshot",
+	Short: "Record the board status into a file for offline triage",
+}
+
+// snapshotSaveCmd represents the snapshot save command
+var snapshotSaveCmd = &cobra.Command{
+	Use:   "save <file>",
+	Short: "Fetch the boards and save them with the raw TestGrid payloads",
+	Args:  cobra.ExactArgs(1),
+	RunE:  RunSnapshotSave,
+}
+
+func init() {
+	rootCmd.AddCommand(snapshotCmd)
+	snapshotCmd.AddCommand(snapshotSaveCmd)
+
+	addFetchFlags(snapshotSaveCmd)
+}
+
+// RunSnapshotSave fetches the dashboards and writes the snapshot archive.
+func RunSnapshotSave(cmd *cobra.Command, args []string) error {
+	recorder := snapshot.NewRecorder(newTransport())
+	dashboards, err := setupTestGrid(cmd.Context(), recorder)
+	if err != nil {
+		return err
+	}
+
+	dashboardTabs, err := fetchWithProgress(cmd.Context(), dashboards)
+	if err != nil {
+		return err
+	}
+
+	if err := snapshot.Save(args[0], snapshot.New(dashboardNamesOf(dashboards), dashboardTabs, recorder.Payloads())); err != nil {
+		return err
+	}
+	fmt.Printf("saved %d tabs to %s\n", len(dashboardTabs), args[0])
+	return nil
+}
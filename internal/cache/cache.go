@@ -0,0 +1,171 @@
+package cache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultMaxAge is how long an entry is kept on disk after its last write.
+	DefaultMaxAge = 7 * 24 * time.Hour
+
+	// DefaultMaxBodySize keeps the large bodies, such as the build logs, out of the cache.
+	DefaultMaxBodySize = 4 << 20
+
+	// pruneInterval spaces the scans for expired entries done on write.
+	pruneInterval = time.Hour
+
+	entryExt = ".json"
+)
+
+// Entry is a cached HTTP response body with its validators.
+type Entry struct {
+	URL          string    `json:"url"`
+	ETag         string    `json:"etag,omitempty"`
+	LastModified string    `json:"last_modified,omitempty"`
+	StoredAt     time.Time `json:"stored_at"`
+	Body         []byte    `json:"body"`
+}
+
+// Fresh returns true if the entry is younger than the TTL.
+func (e *Entry) Fresh(ttl time.Duration) bool {
+	return ttl > 0 && time.Since(e.StoredAt) < ttl
+}
+
+// Cache stores responses on disk, one file per URL.
+type Cache struct {
+	Dir string
+	TTL time.Duration
+	// MaxAge prunes the entries not written for longer, 0 keeps them.
+	MaxAge time.Duration
+	// MaxBodySize skips the larger bodies, 0 stores any size.
+	MaxBodySize int
+
+	pruneMu  sync.Mutex
+	prunedAt time.Time
+}
+
+// New returns a cache writing under dir, entries younger than ttl are
+// served without contacting the server. Bodies above DefaultMaxBodySize are
+// not stored and entries are pruned after DefaultMaxAge.
+func New(dir string, ttl time.Duration) *Cache {
+	return &Cache{Dir: dir, TTL: ttl, MaxAge: DefaultMaxAge, MaxBodySize: DefaultMaxBodySize}
+}
+
+// DefaultDir returns the cache directory, honouring XDG_CACHE_HOME.
+func DefaultDir() string {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return filepath.Join(os.TempDir(), "signalhound")
+	}
+	return filepath.Join(dir, "signalhound")
+}
+
+// Get returns the entry stored for the URL, if any.
+func (c *Cache) Get(url string) (*Entry, bool) {
+	data, err := os.ReadFile(c.path(url))
+	if err != nil {
+		return nil, false
+	}
+	var entry Entry
+	if err := json.Unmarshal(data, &entry); err != nil || entry.URL != url {
+		return nil, false
+	}
+	return &entry, true
+}
+
+// Put stores the entry, replacing the previous one atomically, and prunes the
+// expired entries at most once per hour. An entry whose body is above the
+// size cap is dropped instead.
+func (c *Cache) Put(entry *Entry) error {
+	if entry.URL == "" {
+		return errors.New("cache entry has no URL")
+	}
+	if c.MaxBodySize > 0 && len(entry.Body) > c.MaxBodySize {
+		if err := os.Remove(c.path(entry.URL)); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+		return nil
+	}
+	if err := c.write(entry); err != nil {
+		return err
+	}
+	return c.pruneExpired()
+}
+
+// Prune removes the entries last written before the given time.
+func (c *Cache) Prune(before time.Time) error {
+	files, err := os.ReadDir(c.Dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	for _, file := range files {
+		if file.IsDir() || !strings.HasSuffix(file.Name(), entryExt) {
+			continue
+		}
+		info, err := file.Info()
+		if err != nil || !info.ModTime().Before(before) {
+			continue
+		}
+		if err := os.Remove(filepath.Join(c.Dir, file.Name())); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+	}
+	return nil
+}
+
+// pruneExpired prunes the entries older than MaxAge, unless done recently.
+func (c *Cache) pruneExpired() error {
+	if c.MaxAge <= 0 {
+		return nil
+	}
+	c.pruneMu.Lock()
+	defer c.pruneMu.Unlock()
+	now := time.Now()
+	if now.Sub(c.prunedAt) < pruneInterval {
+		return nil
+	}
+	c.prunedAt = now
+	return c.Prune(now.Add(-c.MaxAge))
+}
+
+// write stores the entry in a temporary file renamed over the previous one.
+func (c *Cache) write(entry *Entry) error {
+	if err := os.MkdirAll(c.Dir, 0o755); err != nil {
+		return err
+	}
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(c.Dir, ".entry-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name()) // nolint: errcheck
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close() // nolint: errcheck
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), c.path(entry.URL))
+}
+
+// path returns the file name of the URL entry.
+func (c *Cache) path(url string) string {
+	sum := sha256.Sum256([]byte(url))
+	return filepath.Join(c.Dir, hex.EncodeToString(sum[:])+entryExt)
+}
@@ -0,0 +1,133 @@
+package cache
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Transport(t *testing.T) {
+	tests := []struct {
+		name          string
+		ttl           time.Duration
+		etag          string
+		revalidate    bool
+		expectedCalls int
+		expectedHits  int
+	}{
+		{
+			name:          "fresh entries skip the network",
+			ttl:           time.Hour,
+			expectedCalls: 1,
+		},
+		{
+			name:          "fresh entries are revalidated on refresh",
+			ttl:           time.Hour,
+			etag:          `"v1"`,
+			revalidate:    true,
+			expectedCalls: 3,
+			expectedHits:  2,
+		},
+		{
+			name:          "stale entries are revalidated by etag",
+			etag:          `"v1"`,
+			expectedCalls: 3,
+			expectedHits:  2,
+		},
+		{
+			name:          "stale entries without validators are fetched again",
+			expectedCalls: 3,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls, notModified int
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				calls++
+				if tt.etag != "" {
+					if r.Header.Get("If-None-Match") == tt.etag {
+						notModified++
+						w.WriteHeader(http.StatusNotModified)
+						return
+					}
+					w.Header().Set("ETag", tt.etag)
+				}
+				w.Write([]byte("payload")) // nolint
+			}))
+			defer server.Close()
+
+			client := &http.Client{Transport: &Transport{Cache: New(t.TempDir(), tt.ttl)}}
+			ctx := context.Background()
+			if tt.revalidate {
+				ctx = WithRevalidate(ctx)
+			}
+			for range 3 {
+				request, _ := http.NewRequestWithContext(ctx, http.MethodGet, server.URL+"/table?tab=ci", nil)
+				response, err := client.Do(request)
+				assert.NoError(t, err)
+				body, _ := io.ReadAll(response.Body)
+				response.Body.Close() // nolint: errcheck
+				assert.Equal(t, http.StatusOK, response.StatusCode)
+				assert.Equal(t, "payload", string(body))
+			}
+			assert.Equal(t, tt.expectedCalls, calls)
+			assert.Equal(t, tt.expectedHits, notModified)
+		})
+	}
+}
+
+func Test_TransportSkipsErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	c := New(t.TempDir(), time.Hour)
+	response, err := (&http.Client{Transport: &Transport{Cache: c}}).Get(server.URL)
+	assert.NoError(t, err)
+	response.Body.Close() // nolint: errcheck
+	assert.Equal(t, http.StatusInternalServerError, response.StatusCode)
+
+	_, found := c.Get(server.URL)
+	assert.False(t, found)
+}
+
+func Test_CacheMaxBodySize(t *testing.T) {
+	c := New(t.TempDir(), time.Hour)
+	c.MaxBodySize = 4
+
+	assert.NoError(t, c.Put(&Entry{URL: "https://example.com/small", Body: []byte("tiny")}))
+	_, found := c.Get("https://example.com/small")
+	assert.True(t, found)
+
+	// a large body replacing a cached one drops the entry
+	assert.NoError(t, c.Put(&Entry{URL: "https://example.com/small", Body: []byte("build log")}))
+	_, found = c.Get("https://example.com/small")
+	assert.False(t, found)
+}
+
+func Test_CachePrune(t *testing.T) {
+	c := New(t.TempDir(), time.Hour)
+	assert.NoError(t, c.Put(&Entry{URL: "https://example.com/old", Body: []byte("old")}))
+	old := time.Now().Add(-2 * DefaultMaxAge)
+	assert.NoError(t, os.Chtimes(c.path("https://example.com/old"), old, old))
+
+	// pruned on the next write once the interval elapsed
+	c.prunedAt = time.Time{}
+	assert.NoError(t, c.Put(&Entry{URL: "https://example.com/new", Body: []byte("new")}))
+	_, found := c.Get("https://example.com/old")
+	assert.False(t, found)
+	_, found = c.Get("https://example.com/new")
+	assert.True(t, found)
+
+	assert.NoError(t, c.Prune(time.Now().Add(time.Minute)))
+	_, found = c.Get("https://example.com/new")
+	assert.False(t, found)
+}
@@ -0,0 +1,114 @@
+package cache
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// Transport is an http.RoundTripper serving GET requests from the cache.
+// Fresh entries are returned without network access unless the request
+// context asks to revalidate, stale ones are revalidated with If-None-Match
+// and If-Modified-Since.
+type Transport struct {
+	Cache *Cache
+	Base  http.RoundTripper
+}
+
+type revalidateKey struct{}
+
+// WithRevalidate returns a context whose requests revalidate the fresh entries
+// too, for the periodic refreshes that must not be served the previous response.
+func WithRevalidate(ctx context.Context) context.Context {
+	return context.WithValue(ctx, revalidateKey{}, true)
+}
+
+func revalidate(ctx context.Context) bool {
+	value, _ := ctx.Value(revalidateKey{}).(bool)
+	return value
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *Transport) RoundTrip(request *http.Request) (*http.Response, error) {
+	if request.Method != http.MethodGet {
+		return t.base().RoundTrip(request)
+	}
+
+	url := request.URL.String()
+	entry, found := t.Cache.Get(url)
+	if found && entry.Fresh(t.Cache.TTL) && !revalidate(request.Context()) {
+		return entry.response(request), nil
+	}
+
+	if found {
+		request = request.Clone(request.Context())
+		if entry.ETag != "" {
+			request.Header.Set("If-None-Match", entry.ETag)
+		}
+		if entry.LastModified != "" {
+			request.Header.Set("If-Modified-Since", entry.LastModified)
+		}
+	}
+
+	response, err := t.base().RoundTrip(request)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case found && response.StatusCode == http.StatusNotModified:
+		response.Body.Close() // nolint: errcheck
+		entry.StoredAt = time.Now()
+		_ = t.Cache.Put(entry)
+		return entry.response(request), nil
+
+	case response.StatusCode == http.StatusOK:
+		body, err := io.ReadAll(response.Body)
+		response.Body.Close() // nolint: errcheck
+		if err != nil {
+			return nil, fmt.Errorf("error reading response body: %v", err)
+		}
+		_ = t.Cache.Put(&Entry{
+			URL:          url,
+			ETag:         response.Header.Get("ETag"),
+			LastModified: response.Header.Get("Last-Modified"),
+			StoredAt:     time.Now(),
+			Body:         body,
+		})
+		response.Body = io.NopCloser(bytes.NewReader(body))
+		return response, nil
+	}
+	return response, nil
+}
+
+func (t *Transport) base() http.RoundTripper {
+	if t.Base == nil {
+		return http.DefaultTransport
+	}
+	return t.Base
+}
+
+// response builds a 200 answer from the cached body.
+func (e *Entry) response(request *http.Request) *http.Response {
+	header := http.Header{}
+	if e.ETag != "" {
+		header.Set("ETag", e.ETag)
+	}
+	if e.LastModified != "" {
+		header.Set("Last-Modified", e.LastModified)
+	}
+	return &http.Response{
+		Status:        "200 OK",
+		StatusCode:    http.StatusOK,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(e.Body)),
+		ContentLength: int64(len(e.Body)),
+		Request:       request,
+	}
+}
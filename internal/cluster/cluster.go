@@ -0,0 +1,124 @@
+package cluster
+
+import (
+	"slices"
+	"sort"
+
+	"sigs.k8s.io/signalhound/api/v1alpha1"
+	"sigs.k8s.io/signalhound/internal/truncate"
+)
+
+const (
+	// DefaultThreshold is the similarity above which two failures share a cluster.
+	DefaultThreshold = 0.8
+
+	// maxTextLength caps the compared text, long messages are dominated by their head.
+	maxTextLength = 2000
+)
+
+// Member is a failing test of a cluster.
+type Member struct {
+	Tab  *v1alpha1.DashboardTab
+	Test v1alpha1.TestResult
+}
+
+// Cluster groups the failures sharing a similar normalized message.
+type Cluster struct {
+	// Text is the normalized message of the first failure of the cluster.
+	Text    string
+	Members []Member
+
+	ngrams map[string]int
+}
+
+// Jobs returns the distinct Board#Tab of the members, in order of appearance.
+func (c *Cluster) Jobs() []string {
+	var jobs []string
+	for _, member := range c.Members {
+		if !slices.Contains(jobs, member.Tab.BoardHash) {
+			jobs = append(jobs, member.Tab.BoardHash)
+		}
+	}
+	return jobs
+}
+
+// Build groups the tests of all tabs by the similarity of their normalized
+// error messages, tests without a message are skipped. Clusters with more
+// members come first.
+func Build(tabs []*v1alpha1.DashboardTab, threshold float64) []Cluster {
+	var clusters []*Cluster
+	exact := map[string]*Cluster{}
+	for _, tab := range tabs {
+		for _, test := range tab.TestRuns {
+			text := Normalize(test.ErrorMessage)
+			text = truncate.Head(text, maxTextLength)
+			if text == "" {
+				continue
+			}
+			member := Member{Tab: tab, Test: test}
+
+			if cluster, ok := exact[text]; ok {
+				cluster.Members = append(cluster.Members, member)
+				continue
+			}
+
+			ngrams := trigrams(text)
+			var best *Cluster
+			var bestScore float64
+			for _, cluster := range clusters {
+				if score := similarity(ngrams, cluster.ngrams); score >= threshold && score > bestScore {
+					best, bestScore = cluster, score
+				}
+			}
+			if best == nil {
+				best = &Cluster{Text: text, ngrams: ngrams}
+				clusters = append(clusters, best)
+			}
+			best.Members = append(best.Members, member)
+			exact[text] = best
+		}
+	}
+
+	result := make([]Cluster, 0, len(clusters))
+	for _, cluster := range clusters {
+		result = append(result, *cluster)
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		return len(result[i].Members) > len(result[j].Members)
+	})
+	return result
+}
+
+// Similarity returns the Dice coefficient of the character trigrams of two
+// texts, from 0 for unrelated texts to 1 for the same text.
+func Similarity(a, b string) float64 {
+	return similarity(trigrams(a), trigrams(b))
+}
+
+func similarity(a, b map[string]int) float64 {
+	var total, shared int
+	for ngram, count := range a {
+		total += count
+		shared += min(count, b[ngram])
+	}
+	for _, count := range b {
+		total += count
+	}
+	if total == 0 {
+		return 1
+	}
+	return 2 * float64(shared) / float64(total)
+}
+
+func trigrams(text string) map[string]int {
+	ngrams := map[string]int{}
+	runes := []rune(text)
+	if len(runes) < 3 {
+		ngrams[text]++
+		return ngrams
+	}
+	for i := 0; i+3 <= len(runes); i++ {
+		ngrams[string(runes[i:i+3])]++
+	}
+	return ngrams
+}
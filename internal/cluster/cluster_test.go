@@ -0,0 +1,97 @@
+package cluster
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/assert"
+	"sigs.k8s.io/signalhound/api/v1alpha1"
+)
+
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		name     string
+		message  string
+		expected string
+	}{
+		{
+			name:     "uuid and ip",
+			message:  "pod 3f2b1c4e-8d9a-4b1c-9e2f-1a2b3c4d5e6f unreachable at 10.64.1.23:10250",
+			expected: "pod UUID unreachable at IP",
+		},
+		{
+			name:     "timestamps",
+			message:  "\tF 2025-03-04 10:11:12 +0000 UTC timed out at 2025-03-04T10:11:12.123Z",
+			expected: "F TIME timed out at TIME",
+		},
+		{
+			name:     "klog header",
+			message:  "E0304 10:11:12.123456 failed",
+			expected: "TIME failed",
+		},
+		{
+			name:     "pod names",
+			message:  "coredns-5d78c9869d-x2bzq and etcd-kind-4bgfz not ready in e2e-tests",
+			expected: "POD and POD not ready in e2e-tests",
+		},
+		{
+			name:     "repeated lines",
+			message:  "\tF 2025-03-04 10:11:12 +0000 UTC boom\n\tF 2025-03-03 09:00:00 +0000 UTC boom\n",
+			expected: "F TIME boom",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, Normalize(tt.message))
+		})
+	}
+}
+
+func TestSimilarity(t *testing.T) {
+	assert.Equal(t, 1.0, Similarity("cluster bring-up timed out", "cluster bring-up timed out"))
+	assert.Greater(t, Similarity("cluster bring-up timed out after 30m", "cluster bring-up timed out after 45m"), DefaultThreshold)
+	assert.Less(t, Similarity("cluster bring-up timed out", "expected 3 replicas, got 2"), DefaultThreshold)
+}
+
+func TestBuild(t *testing.T) {
+	gce := &v1alpha1.DashboardTab{
+		BoardHash: "sig-release-master-blocking#gce",
+		TestRuns: []v1alpha1.TestResult{
+			{TestName: "a", ErrorMessage: "cluster bring-up timed out waiting for node 10.0.0.1"},
+			{TestName: "b", ErrorMessage: "expected 3 replicas, got 2"},
+			{TestName: "c"},
+		},
+	}
+	kind := &v1alpha1.DashboardTab{
+		BoardHash: "sig-release-master-blocking#kind",
+		TestRuns: []v1alpha1.TestResult{
+			{TestName: "a", ErrorMessage: "cluster bring-up timed out waiting for node 10.0.0.2"},
+			{TestName: "d", ErrorMessage: "cluster bring-up timed out waiting for nodes 192.168.0.7"},
+		},
+	}
+
+	clusters := Build([]*v1alpha1.DashboardTab{gce, kind}, DefaultThreshold)
+	assert.Len(t, clusters, 2)
+
+	assert.Equal(t, "cluster bring-up timed out waiting for node IP", clusters[0].Text)
+	assert.Len(t, clusters[0].Members, 3)
+	assert.Equal(t, []string{gce.BoardHash, kind.BoardHash}, clusters[0].Jobs())
+
+	assert.Len(t, clusters[1].Members, 1)
+	assert.Equal(t, "b", clusters[1].Members[0].Test.TestName)
+}
+
+func TestBuildTruncatesOnRuneBoundaries(t *testing.T) {
+	tab := &v1alpha1.DashboardTab{
+		TestRuns: []v1alpha1.TestResult{
+			{TestName: "a", ErrorMessage: "a" + strings.Repeat("é", maxTextLength)},
+		},
+	}
+
+	clusters := Build([]*v1alpha1.DashboardTab{tab}, DefaultThreshold)
+	assert.Len(t, clusters, 1)
+	assert.True(t, utf8.ValidString(clusters[0].Text))
+	assert.LessOrEqual(t, len(clusters[0].Text), maxTextLength)
+}
@@ -0,0 +1,46 @@
+package cluster
+
+import (
+	"regexp"
+	"strings"
+)
+
+// replacement rewrites the volatile parts of a failure message, the order
+// matters as timestamps would otherwise be read as IPv6 addresses.
+type replacement struct {
+	regex *regexp.Regexp
+	value string
+}
+
+var replacements = []replacement{
+	{regexp.MustCompile(`(?i)\b[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}\b`), "UUID"},
+	// 2025-01-02T15:04:05.000Z, 2025-01-02 15:04:05 +0000 UTC
+	{regexp.MustCompile(`\b\d{4}-\d{2}-\d{2}[T ]\d{2}:\d{2}:\d{2}(\.\d+)?(Z|\s?[+-]\d{2}:?\d{2})?(\s[A-Z]{2,5}\b)?`), "TIME"},
+	// klog headers, I0102 15:04:05.000000
+	{regexp.MustCompile(`\b[IWEF]\d{4} \d{2}:\d{2}:\d{2}(\.\d+)?`), "TIME"},
+	{regexp.MustCompile(`\b\d{2}:\d{2}:\d{2}(\.\d+)?\b`), "TIME"},
+	{regexp.MustCompile(`\b\d{1,3}(\.\d{1,3}){3}(:\d+)?\b`), "IP"},
+	{regexp.MustCompile(`(?i)\b([0-9a-f]{1,4}:){2,7}[0-9a-f]{1,4}\b`), "IP"},
+	// generated pod names, the random suffix alphabet has no vowels
+	{regexp.MustCompile(`\b[a-z0-9]([-a-z0-9]*[a-z0-9])?(-[0-9a-f]{8,10})?-[bcdfghjklmnpqrstvwxz2456789]{5}\b`), "POD"},
+}
+
+// Normalize strips UUIDs, timestamps, IPs and pod names from a failure
+// message and drops repeated lines, so failures with the same root cause
+// produce the same text.
+func Normalize(message string) string {
+	var lines []string
+	seen := map[string]bool{}
+	for _, line := range strings.Split(message, "\n") {
+		for _, r := range replacements {
+			line = r.regex.ReplaceAllString(line, r.value)
+		}
+		line = strings.Join(strings.Fields(line), " ")
+		if line == "" || seen[line] {
+			continue
+		}
+		seen[line] = true
+		lines = append(lines, line)
+	}
+	return strings.Join(lines, "\n")
+}
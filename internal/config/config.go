@@ -0,0 +1,112 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"sigs.k8s.io/yaml"
+
+	"sigs.k8s.io/signalhound/internal/github"
+	"sigs.k8s.io/signalhound/internal/links"
+)
+
+// DefaultDashboards are scraped when neither flags nor the config file list any board.
+var DefaultDashboards = []string{"sig-release-master-blocking", "sig-release-master-informing"}
+
+// Config holds the signalhound settings loaded from the config file.
+type Config struct {
+	// Dashboards is the list of TestGrid dashboards to summarize.
+	Dashboards []Dashboard `json:"dashboards,omitempty"`
+
+	// Links overrides the TestGrid, Prow, artifact and triage base URLs.
+	Links links.Config `json:"links,omitempty"`
+
+	// GitHub overrides the organization, project and field mapping of the drafts.
+	GitHub github.ProjectConfig `json:"github,omitempty"`
+}
+
+// Dashboard is a TestGrid dashboard with optional threshold overrides.
+type Dashboard struct {
+	// Name is the TestGrid dashboard name, e.g. sig-release-master-blocking.
+	Name string `json:"name"`
+
+	// MinFailure overrides the global minimum failure threshold when set.
+	MinFailure *int `json:"minFailure,omitempty"`
+
+	// MinFlake overrides the global minimum flake threshold when set.
+	MinFlake *int `json:"minFlake,omitempty"`
+}
+
+// Thresholds returns the failure and flake thresholds for the dashboard,
+// falling back to the given defaults when no override is set.
+func (d Dashboard) Thresholds(minFailure, minFlake int) (int, int) {
+	if d.MinFailure != nil {
+		minFailure = *d.MinFailure
+	}
+	if d.MinFlake != nil {
+		minFlake = *d.MinFlake
+	}
+	return minFailure, minFlake
+}
+
+// DefaultPath returns the default config file location, honouring XDG_CONFIG_HOME.
+func DefaultPath() string {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "signalhound", "config.yaml")
+}
+
+// Load reads the config file from path. A missing file yields an empty config.
+func Load(path string) (*Config, error) {
+	cfg := &Config{}
+	if path == "" {
+		return cfg, nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return cfg, nil
+		}
+		return nil, fmt.Errorf("error reading config file: %v", err)
+	}
+	if err = yaml.UnmarshalStrict(data, cfg); err != nil {
+		return nil, fmt.Errorf("error parsing config file %s: %v", path, err)
+	}
+	for i, dashboard := range cfg.Dashboards {
+		if dashboard.Name == "" {
+			return nil, fmt.Errorf("dashboard entry %d in %s has no name", i, path)
+		}
+	}
+	return cfg, nil
+}
+
+// ResolveDashboards returns the dashboards to scrape. Names given on the
+// command line take precedence and keep any overrides from the config file,
+// otherwise the config file list is used, and finally the defaults.
+func (c *Config) ResolveDashboards(names []string) []Dashboard {
+	if len(names) == 0 {
+		if len(c.Dashboards) > 0 {
+			return c.Dashboards
+		}
+		names = DefaultDashboards
+	}
+
+	configured := make(map[string]Dashboard, len(c.Dashboards))
+	for _, dashboard := range c.Dashboards {
+		configured[dashboard.Name] = dashboard
+	}
+
+	dashboards := make([]Dashboard, 0, len(names))
+	for _, name := range names {
+		if dashboard, ok := configured[name]; ok {
+			dashboards = append(dashboards, dashboard)
+			continue
+		}
+		dashboards = append(dashboards, Dashboard{Name: name})
+	}
+	return dashboards
+}
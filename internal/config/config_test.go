@@ -0,0 +1,144 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"sigs.k8s.io/signalhound/internal/github"
+	"sigs.k8s.io/signalhound/internal/links"
+)
+
+func Test_Load(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		expected []Dashboard
+		hasError bool
+	}{
+		{
+			name: "dashboards with overrides",
+			content: `dashboards:
+- name: sig-release-1.34-blocking
+  minFailure: 2
+- name: sig-node-release-blocking
+  minFlake: 5
+`,
+			expected: []Dashboard{
+				{Name: "sig-release-1.34-blocking", MinFailure: intPtr(2)},
+				{Name: "sig-node-release-blocking", MinFlake: intPtr(5)},
+			},
+		},
+		{
+			name:     "dashboard without name",
+			content:  "dashboards:\n- minFailure: 2\n",
+			hasError: true,
+		},
+		{
+			name:     "unknown field",
+			content:  "boards: []\n",
+			hasError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "config.yaml")
+			assert.NoError(t, os.WriteFile(path, []byte(tt.content), 0o600))
+
+			cfg, err := Load(path)
+			if tt.hasError {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, cfg.Dashboards)
+		})
+	}
+}
+
+func Test_LoadLinks(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	content := "links:\n  prowURL: https://prow.example.com\n  storage: s3\n"
+	assert.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+
+	cfg, err := Load(path)
+	assert.NoError(t, err)
+	assert.Equal(t, links.Config{ProwURL: "https://prow.example.com", Storage: "s3"}, cfg.Links)
+}
+
+func Test_LoadGitHub(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	content := `github:
+  organization: my-org
+  projectNumber: 12
+  fields:
+    Status: Triage
+    Release: $latest
+`
+	assert.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+
+	cfg, err := Load(path)
+	assert.NoError(t, err)
+	expected := github.ProjectConfig{
+		Organization:  "my-org",
+		ProjectNumber: 12,
+		Fields:        map[string]string{"Status": "Triage", "Release": github.LatestReleaseOption},
+	}
+	assert.Equal(t, expected, cfg.GitHub)
+	assert.NoError(t, cfg.GitHub.Validate())
+
+	// the flags override the project number and keep the mapping of the file
+	resolved := cfg.GitHub.Override(github.ProjectConfig{ProjectNumber: 3}).WithDefaults()
+	assert.Equal(t, 3, resolved.ProjectNumber)
+	assert.Equal(t, expected.Fields, resolved.Fields)
+	// the code repository does not follow the organization of the project
+	assert.Equal(t, github.DefaultRepository, resolved.Repository)
+	assert.Error(t, github.ProjectConfig{Organization: "my-org"}.Validate())
+	assert.Equal(t, github.DefaultFields, github.ProjectConfig{}.WithDefaults().Fields)
+}
+
+func Test_LoadMissingFile(t *testing.T) {
+	cfg, err := Load(filepath.Join(t.TempDir(), "missing.yaml"))
+	assert.NoError(t, err)
+	assert.Empty(t, cfg.Dashboards)
+}
+
+func Test_ResolveDashboards(t *testing.T) {
+	cfg := &Config{Dashboards: []Dashboard{
+		{Name: "sig-release-1.34-blocking", MinFailure: intPtr(4)},
+		{Name: "sig-node-release-blocking"},
+	}}
+
+	tests := []struct {
+		name     string
+		cfg      *Config
+		flags    []string
+		expected []string
+	}{
+		{name: "defaults", cfg: &Config{}, expected: DefaultDashboards},
+		{name: "config file", cfg: cfg, expected: []string{"sig-release-1.34-blocking", "sig-node-release-blocking"}},
+		{name: "flags win", cfg: cfg, flags: []string{"sig-release-1.34-blocking", "private"}, expected: []string{"sig-release-1.34-blocking", "private"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var names []string
+			for _, dashboard := range tt.cfg.ResolveDashboards(tt.flags) {
+				names = append(names, dashboard.Name)
+			}
+			assert.Equal(t, tt.expected, names)
+		})
+	}
+
+	// overrides from the config file are kept for dashboards given by flag
+	minFailure, minFlake := cfg.ResolveDashboards([]string{"sig-release-1.34-blocking"})[0].Thresholds(1, 3)
+	assert.Equal(t, 4, minFailure)
+	assert.Equal(t, 3, minFlake)
+}
+
+func intPtr(i int) *int {
+	return &i
+}
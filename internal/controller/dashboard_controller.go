@@ -18,16 +18,26 @@ package controller
 
 import (
 	"context"
+	"errors"
+	"path"
 	"reflect"
+	"slices"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/go-logr/logr"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	testgridv1alpha1 "sigs.k8s.io/signalhound/api/v1alpha1"
+	"sigs.k8s.io/signalhound/internal/history"
+	"sigs.k8s.io/signalhound/internal/links"
+	"sigs.k8s.io/signalhound/internal/prow"
 	"sigs.k8s.io/signalhound/internal/testgrid"
 
 	"go.opentelemetry.io/otel"
@@ -39,17 +49,24 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/metrics"
 )
 
-const meterName = "signalhound"
+const (
+	meterName = "signalhound"
+
+	// rateLimitRequeue is how long to wait before reconciling again when TestGrid rate limits us
+	rateLimitRequeue = 5 * time.Minute
+)
 
 // Metrics holds OpenTelemetry metric instruments
 type Metrics struct {
 	dashboardStateGauge metric.Int64Gauge
 	tabStateGauge       metric.Int64Gauge
+	tabStaleGauge       metric.Int64Gauge
 	lastRunTimestamp    metric.Int64Gauge
 	lastUpdateTimestamp metric.Int64Gauge
 	totalTestFailures   metric.Int64Gauge
 	totalTestFlakes     metric.Int64Gauge
 	testFailuresCounter metric.Int64Counter
+	jobDuration         metric.Float64Histogram
 }
 
 // globalMetrics holds the initialized metrics
@@ -91,6 +108,15 @@ func initMetrics() error {
 		return err
 	}
 
+	tabStaleGauge, err := meter.Int64Gauge(
+		"testgrid_tab_stale",
+		metric.WithDescription("Whether the tab missed its usual runs (1 = stale)"),
+		metric.WithUnit("1"),
+	)
+	if err != nil {
+		return err
+	}
+
 	lastRunTimestamp, err := meter.Int64Gauge(
 		"testgrid_dashboard_last_run_timestamp",
 		metric.WithDescription("Unix timestamp of the last test run for a dashboard tab"),
@@ -136,14 +162,26 @@ func initMetrics() error {
 		return err
 	}
 
+	jobDuration, err := meter.Float64Histogram(
+		"testgrid_job_duration_seconds",
+		metric.WithDescription("Duration of the finished builds of the failing and flaky jobs"),
+		metric.WithUnit("s"),
+		metric.WithExplicitBucketBoundaries(60, 300, 600, 900, 1200, 1800, 2700, 3600, 5400, 7200, 10800, 14400),
+	)
+	if err != nil {
+		return err
+	}
+
 	globalMetrics = &Metrics{
 		dashboardStateGauge: dashboardStateGauge,
 		tabStateGauge:       tabStateGauge,
+		tabStaleGauge:       tabStaleGauge,
 		lastRunTimestamp:    lastRunTimestamp,
 		lastUpdateTimestamp: lastUpdateTimestamp,
 		totalTestFailures:   totalTestFailures,
 		totalTestFlakes:     totalTestFlakes,
 		testFailuresCounter: testFailuresCounter,
+		jobDuration:         jobDuration,
 	}
 
 	return nil
@@ -153,7 +191,31 @@ func initMetrics() error {
 type DashboardReconciler struct {
 	client.Client
 	Scheme *runtime.Scheme
-	log    logr.Logger
+	// History stores every fetched tab when set
+	History *history.Store
+	// Links are the base URLs of TestGrid, Prow, the artifacts and triage,
+	// the upstream Kubernetes ones when unset
+	Links links.Config
+	// JobHistoryBuilds is the number of builds read from the Prow job history
+	// of each failing or flaky tab, 0 disables the job duration metric
+	JobHistoryBuilds int
+	log              logr.Logger
+
+	// seenBuilds holds the IDs of the builds recorded per job, builds are recorded once
+	seenBuilds   map[string]map[string]bool
+	seenBuildsMu sync.Mutex
+
+	// boardTabs holds the latest scrape of each Dashboard, every history entry
+	// merges them into a snapshot of all the boards
+	boardTabs   map[types.NamespacedName]scrapedBoards
+	boardTabsMu sync.Mutex
+}
+
+// scrapedBoards are the TestGrid dashboards scraped for a Dashboard, passing
+// ones included, and their failing or flaky tabs.
+type scrapedBoards struct {
+	dashboards []string
+	tabs       []*testgridv1alpha1.DashboardTab
 }
 
 // +kubebuilder:rbac:groups=testgrid.holdmybeer.io,resources=dashboards,verbs=get;list;watch;create;update;patch;delete
@@ -176,17 +238,45 @@ func (r *DashboardReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 
 	var dashboard testgridv1alpha1.Dashboard
 	if err := r.Get(ctx, req.NamespacedName, &dashboard); err != nil {
+		if apierrors.IsNotFound(err) {
+			r.forgetTabs(req.NamespacedName)
+		}
 		r.log.Error(err, "unable to fetch dashboard")
 		span.RecordError(err)
 		return ctrl.Result{}, client.IgnoreNotFound(err)
 	}
 
-	grid := testgrid.NewTestGrid(testgrid.URL)
-	dashboardSummaries, err := grid.FetchTabSummary(dashboard.Spec.DashboardTab, testgridv1alpha1.ERROR_STATUSES)
+	// an unset multiplier keeps the default, 0 disables the stale check
+	staleMultiplier := testgrid.DefaultStaleMultiplier
+	if dashboard.Spec.StaleMultiplier != nil {
+		staleMultiplier = *dashboard.Spec.StaleMultiplier
+	}
+	linkConfig := r.Links.WithDefaults()
+	grid := testgrid.NewTestGrid(linkConfig.TestGridURL,
+		testgrid.WithLinks(linkConfig),
+		testgrid.WithStaleMultiplier(staleMultiplier),
+	)
+	dashboardNames, err := r.dashboardNames(ctx, grid, dashboard.Spec)
 	if err != nil {
-		r.log.Error(err, "error fetching summary from endpoint.")
+		r.log.Error(err, "error discovering release dashboards.")
 		span.RecordError(err)
-		return ctrl.Result{}, err
+		return requeueOnError(err)
+	}
+
+	statuses := testgridv1alpha1.ERROR_STATUSES
+	if dashboard.Spec.IncludeStale {
+		statuses = append([]string{testgridv1alpha1.PASSING_STATUS}, statuses...)
+	}
+
+	var dashboardSummaries []testgridv1alpha1.DashboardSummary
+	for _, dashboardName := range dashboardNames {
+		summaries, err := grid.FetchTabSummary(ctx, dashboardName, statuses)
+		if err != nil {
+			r.log.Error(err, "error fetching summary from endpoint.", "dashboard", dashboardName)
+			span.RecordError(err)
+			return requeueOnError(err)
+		}
+		dashboardSummaries = append(dashboardSummaries, summaries...)
 	}
 
 	span.SetAttributes(attribute.Int("summaries.count", len(dashboardSummaries)))
@@ -203,11 +293,12 @@ func (r *DashboardReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 			return ctrl.Result{}, err
 		}
 
+		var tabs []*testgridv1alpha1.DashboardTab
 		for _, dashSummary := range dashboardSummaries {
 			tabName := dashSummary.DashboardTab.TabName
 
 			var tab *testgridv1alpha1.DashboardTab
-			if tab, err = grid.FetchTabTests(&dashSummary, dashboard.Spec.MinFlakes, dashboard.Spec.MinFailures); err != nil {
+			if tab, err = grid.FetchTabTests(ctx, &dashSummary, dashboard.Spec.MinFailures, dashboard.Spec.MinFlakes); err != nil {
 				r.log.Error(err, "error fetching table", "tab", tabName)
 				span.RecordError(err)
 				continue
@@ -215,6 +306,18 @@ func (r *DashboardReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 
 			// record metrics for this tab summary
 			r.recordMetrics(ctx, &dashSummary, tab)
+			if r.JobHistoryBuilds > 0 {
+				r.recordJobHistory(ctx, &dashSummary, tab)
+			}
+			tabs = append(tabs, tab)
+		}
+
+		if r.History != nil {
+			dashboards, tabs := r.mergeTabs(req.NamespacedName, scrapedBoards{dashboards: dashboardNames, tabs: tabs})
+			if err := r.History.Append(time.Now(), dashboards, tabs); err != nil {
+				r.log.Error(err, "unable to record the dashboard history")
+				span.RecordError(err)
+			}
 		}
 	}
 
@@ -224,6 +327,94 @@ func (r *DashboardReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 	return ctrl.Result{}, nil
 }
 
+// mergeTabs keeps the scrape of the Dashboard and returns the scraped
+// dashboards and latest tabs of all of them, so a history entry holds every
+// board rather than the one reconciled. A dashboard or tab watched by several
+// Dashboards is kept once.
+func (r *DashboardReconciler) mergeTabs(name types.NamespacedName, scraped scrapedBoards) ([]string, []*testgridv1alpha1.DashboardTab) {
+	r.boardTabsMu.Lock()
+	defer r.boardTabsMu.Unlock()
+	if r.boardTabs == nil {
+		r.boardTabs = map[types.NamespacedName]scrapedBoards{}
+	}
+	r.boardTabs[name] = scraped
+
+	names := make([]types.NamespacedName, 0, len(r.boardTabs))
+	for name := range r.boardTabs {
+		names = append(names, name)
+	}
+	slices.SortFunc(names, func(a, b types.NamespacedName) int {
+		return strings.Compare(a.String(), b.String())
+	})
+
+	var (
+		dashboards []string
+		merged     []*testgridv1alpha1.DashboardTab
+	)
+	seenDashboards, seenTabs := map[string]bool{}, map[string]bool{}
+	for _, name := range names {
+		for _, dashboard := range r.boardTabs[name].dashboards {
+			if !seenDashboards[dashboard] {
+				seenDashboards[dashboard] = true
+				dashboards = append(dashboards, dashboard)
+			}
+		}
+		for _, tab := range r.boardTabs[name].tabs {
+			if !seenTabs[tab.BoardHash] {
+				seenTabs[tab.BoardHash] = true
+				merged = append(merged, tab)
+			}
+		}
+	}
+	return dashboards, merged
+}
+
+// forgetTabs drops the tabs of a deleted Dashboard from the next history entries.
+func (r *DashboardReconciler) forgetTabs(name types.NamespacedName) {
+	r.boardTabsMu.Lock()
+	defer r.boardTabsMu.Unlock()
+	delete(r.boardTabs, name)
+}
+
+// dashboardNames returns the dashboard set in the spec followed by the
+// discovered release branch boards.
+func (r *DashboardReconciler) dashboardNames(ctx context.Context, grid *testgrid.TestGrid, spec testgridv1alpha1.DashboardSpec) ([]string, error) {
+	var names []string
+	if spec.DashboardTab != "" {
+		names = append(names, spec.DashboardTab)
+	}
+	if spec.Releases <= 0 {
+		return names, nil
+	}
+
+	group := spec.DashboardGroup
+	if group == "" {
+		group = testgrid.DefaultDashboardGroup
+	}
+	discovered, err := grid.DiscoverReleaseDashboards(ctx, group, spec.Releases)
+	if err != nil {
+		return nil, err
+	}
+	for _, name := range discovered {
+		if !slices.Contains(names, name) {
+			names = append(names, name)
+		}
+	}
+	return names, nil
+}
+
+// requeueOnError decides how to retry a reconcile that failed talking to TestGrid.
+func requeueOnError(err error) (ctrl.Result, error) {
+	switch {
+	case errors.Is(err, testgrid.ErrNotFound):
+		// the dashboard does not exist, retrying won't help until the spec changes
+		return ctrl.Result{}, nil
+	case errors.Is(err, testgrid.ErrRateLimited):
+		return ctrl.Result{RequeueAfter: rateLimitRequeue}, nil
+	}
+	return ctrl.Result{}, err
+}
+
 // recordMetrics records OpenTelemetry metrics for testgrid dashboard failures and flakes
 func (r *DashboardReconciler) recordMetrics(ctx context.Context, dashSummary *testgridv1alpha1.DashboardSummary, tab *testgridv1alpha1.DashboardTab) {
 	if globalMetrics == nil {
@@ -261,8 +452,9 @@ func (r *DashboardReconciler) recordMetrics(ctx context.Context, dashSummary *te
 	for _, testResult := range tab.TestRuns {
 		testNameAttr := attribute.String("test_name", testResult.TestName)
 		tabState := attribute.String("tab_state", tab.TabState)
+		sigAttr := attribute.String("sig", testResult.Sig)
 		globalMetrics.testFailuresCounter.Add(ctx, 1,
-			metric.WithAttributes(dashboardAttr, tabAttr, testNameAttr, tabState))
+			metric.WithAttributes(dashboardAttr, tabAttr, testNameAttr, tabState, sigAttr))
 	}
 
 	// record aggregate counts based on tab state
@@ -280,6 +472,13 @@ func (r *DashboardReconciler) recordMetrics(ctx context.Context, dashSummary *te
 	globalMetrics.tabStateGauge.Record(ctx, 1,
 		metric.WithAttributes(dashboardAttr, tabAttr, tabStateAttr))
 
+	var stale int64
+	if tab.Stale {
+		stale = 1
+	}
+	globalMetrics.tabStaleGauge.Record(ctx, stale,
+		metric.WithAttributes(dashboardAttr, tabAttr))
+
 	r.log.V(1).Info("recorded metrics",
 		"dashboard", dashboardName,
 		"tab", tabName,
@@ -287,6 +486,62 @@ func (r *DashboardReconciler) recordMetrics(ctx context.Context, dashSummary *te
 		"tests", len(tab.TestRuns))
 }
 
+// recordJobHistory records the duration of the builds of the tab job finished
+// since the last reconcile, including older builds that were still pending.
+func (r *DashboardReconciler) recordJobHistory(ctx context.Context, dashSummary *testgridv1alpha1.DashboardSummary, tab *testgridv1alpha1.DashboardTab) {
+	if globalMetrics == nil {
+		return
+	}
+	var prowJobURL string
+	for _, test := range tab.TestRuns {
+		if test.ProwJobURL != "" {
+			prowJobURL = test.ProwJobURL
+			break
+		}
+	}
+	if prowJobURL == "" {
+		return
+	}
+	jobPath, err := prow.JobPathFromURL(prowJobURL)
+	if err != nil {
+		r.log.V(1).Info("skipping job history", "tab", tab.BoardHash, "error", err.Error())
+		return
+	}
+	builds, err := prow.NewGCS(prow.WithBucketURL(r.Links.WithDefaults().ArtifactURL)).JobHistory(ctx, jobPath, r.JobHistoryBuilds)
+	if err != nil {
+		r.log.Error(err, "error fetching job history", "job", jobPath)
+		return
+	}
+
+	r.seenBuildsMu.Lock()
+	defer r.seenBuildsMu.Unlock()
+	if r.seenBuilds == nil {
+		r.seenBuilds = map[string]map[string]bool{}
+	}
+	// only the builds of the current window are kept, older ones are not listed again
+	seen, recorded := r.seenBuilds[jobPath], map[string]bool{}
+	r.seenBuilds[jobPath] = recorded
+
+	attrs := []attribute.KeyValue{
+		attribute.String("dashboard", dashSummary.DashboardName),
+		attribute.String("tab", dashSummary.DashboardTab.TabName),
+		attribute.String("job", path.Base(jobPath)),
+	}
+	// a pending build finishing after a newer one is recorded on a later reconcile
+	for _, build := range builds {
+		if seen[build.ID] {
+			recorded[build.ID] = true
+			continue
+		}
+		if build.Result == prow.ResultPending {
+			continue
+		}
+		recorded[build.ID] = true
+		globalMetrics.jobDuration.Record(ctx, build.Duration.Seconds(),
+			metric.WithAttributes(append(attrs, attribute.String("result", build.Result))...))
+	}
+}
+
 // shouldRefresh determines if it's time to refresh the dashboard data
 func (r *DashboardReconciler) shouldRefresh(dashboardStatus testgridv1alpha1.DashboardStatus, summary []testgridv1alpha1.DashboardSummary) bool {
 	if reflect.DeepEqual(dashboardStatus.DashboardSummary, summary) {
@@ -0,0 +1,134 @@
+package diff
+
+import (
+	"sort"
+	"strings"
+
+	"sigs.k8s.io/signalhound/api/v1alpha1"
+	"sigs.k8s.io/signalhound/internal/testgrid"
+)
+
+// Change is how a test evolved between two sets of tabs.
+type Change string
+
+const (
+	// New tests were not failing nor flaking before.
+	New Change = "new"
+	// Escalated tests were flaking before and are failing now.
+	Escalated Change = "escalated"
+	// StillFailing tests were already failing or flaking before.
+	StillFailing Change = "still-failing"
+	// Resolved tests are no longer failing nor flaking.
+	Resolved Change = "resolved"
+	// Uncompared tests belong to a board missing from the other set, e.g. not
+	// fetched then, so nothing can be told about them.
+	Uncompared Change = "uncompared"
+)
+
+// changeOrder sorts the changes by urgency.
+var changeOrder = map[Change]int{New: 0, Escalated: 1, StillFailing: 2, Resolved: 3, Uncompared: 4}
+
+// Entry is a test present in either set of tabs.
+type Entry struct {
+	Change    Change `json:"change"`
+	BoardHash string `json:"board_hash"`
+	TestName  string `json:"test_name"`
+	// Before and After are the tab states, empty when the test was absent.
+	Before string `json:"before,omitempty"`
+	After  string `json:"after,omitempty"`
+	// Test is the most recent result of the test.
+	Test v1alpha1.TestResult `json:"test"`
+}
+
+// Side is a board state compared by Compare.
+type Side struct {
+	// Dashboards are the TestGrid dashboards that were scraped, passing ones
+	// included. When empty, the boards of the tabs are taken instead.
+	Dashboards []string
+	Tabs       []*v1alpha1.DashboardTab
+}
+
+type key struct {
+	boardHash, testName string
+}
+
+type state struct {
+	tabState string
+	test     v1alpha1.TestResult
+}
+
+// Compare classifies every test of the before and after tabs. Tests are
+// matched by Board#Tab and normalized name. Passing tabs are not kept, so a tab
+// absent from a board scraped on both sides was passing, while the tests of a
+// board scraped on one side only are Uncompared rather than new or resolved.
+// Entries are ordered by change, new ones first, then by Board#Tab and test name.
+func Compare(before, after Side) []Entry {
+	previous, previousBoards := index(before)
+	current, currentBoards := index(after)
+
+	var entries []Entry
+	for k, now := range current {
+		entry := Entry{BoardHash: k.boardHash, TestName: now.test.TestName, After: now.tabState, Test: now.test, Change: New}
+		if !previousBoards[boardName(k.boardHash)] {
+			entry.Change = Uncompared
+		} else if then, ok := previous[k]; ok {
+			entry.Before = then.tabState
+			entry.Change = StillFailing
+			if then.tabState == v1alpha1.FLAKY_STATUS && now.tabState == v1alpha1.FAILING_STATUS {
+				entry.Change = Escalated
+			}
+		}
+		entries = append(entries, entry)
+	}
+	for k, then := range previous {
+		if _, ok := current[k]; !ok {
+			change := Resolved
+			if !currentBoards[boardName(k.boardHash)] {
+				change = Uncompared
+			}
+			entries = append(entries, Entry{BoardHash: k.boardHash, TestName: then.test.TestName, Before: then.tabState, Test: then.test, Change: change})
+		}
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		a, b := entries[i], entries[j]
+		if a.Change != b.Change {
+			return changeOrder[a.Change] < changeOrder[b.Change]
+		}
+		if a.BoardHash != b.BoardHash {
+			return a.BoardHash < b.BoardHash
+		}
+		return a.TestName < b.TestName
+	})
+	return entries
+}
+
+// Count returns the number of entries per change.
+func Count(entries []Entry) map[Change]int {
+	counts := map[Change]int{}
+	for _, entry := range entries {
+		counts[entry.Change]++
+	}
+	return counts
+}
+
+// index returns the tests by Board#Tab and normalized name, and the scraped boards.
+func index(side Side) (map[key]state, map[string]bool) {
+	tests, boards := map[key]state{}, map[string]bool{}
+	for _, dashboard := range side.Dashboards {
+		boards[dashboard] = true
+	}
+	for _, tab := range side.Tabs {
+		boards[boardName(tab.BoardHash)] = true
+		for _, test := range tab.TestRuns {
+			tests[key{tab.BoardHash, testgrid.NormalizeTestName(test.TestName)}] = state{tabState: tab.TabState, test: test}
+		}
+	}
+	return tests, boards
+}
+
+// boardName returns the dashboard of a Board#Tab.
+func boardName(boardHash string) string {
+	board, _, _ := strings.Cut(boardHash, "#")
+	return board
+}
@@ -0,0 +1,123 @@
+package diff
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"sigs.k8s.io/signalhound/api/v1alpha1"
+)
+
+func tab(boardHash, state string, tests ...string) *v1alpha1.DashboardTab {
+	tab := &v1alpha1.DashboardTab{BoardHash: boardHash, TabState: state}
+	for _, test := range tests {
+		tab.TestRuns = append(tab.TestRuns, v1alpha1.TestResult{TestName: test, ProwJobURL: "https://prow/" + test})
+	}
+	return tab
+}
+
+func TestCompare(t *testing.T) {
+	before := []*v1alpha1.DashboardTab{
+		tab("blocking#gce", v1alpha1.FLAKY_STATUS, "Kubernetes e2e suite.[It] escalated", "fixed"),
+		tab("blocking#kind", v1alpha1.FAILING_STATUS, "persisting"),
+	}
+	after := []*v1alpha1.DashboardTab{
+		tab("blocking#gce", v1alpha1.FAILING_STATUS, "escalated", "brand new"),
+		tab("blocking#kind", v1alpha1.FAILING_STATUS, "persisting"),
+	}
+
+	entries := Compare(Side{Tabs: before}, Side{Tabs: after})
+	assert.Equal(t, []Entry{
+		{Change: New, BoardHash: "blocking#gce", TestName: "brand new", After: v1alpha1.FAILING_STATUS, Test: after[0].TestRuns[1]},
+		{Change: Escalated, BoardHash: "blocking#gce", TestName: "escalated", Before: v1alpha1.FLAKY_STATUS, After: v1alpha1.FAILING_STATUS, Test: after[0].TestRuns[0]},
+		{Change: StillFailing, BoardHash: "blocking#kind", TestName: "persisting", Before: v1alpha1.FAILING_STATUS, After: v1alpha1.FAILING_STATUS, Test: after[1].TestRuns[0]},
+		{Change: Resolved, BoardHash: "blocking#gce", TestName: "fixed", Before: v1alpha1.FLAKY_STATUS, Test: before[0].TestRuns[1]},
+	}, entries)
+	assert.Equal(t, map[Change]int{New: 1, Escalated: 1, StillFailing: 1, Resolved: 1}, Count(entries))
+}
+
+func TestCompareMissingBoards(t *testing.T) {
+	before := []*v1alpha1.DashboardTab{
+		tab("blocking#gce", v1alpha1.FAILING_STATUS, "persisting"),
+		tab("informing#kind", v1alpha1.FAILING_STATUS, "not fetched after"),
+	}
+	after := []*v1alpha1.DashboardTab{
+		tab("blocking#gce", v1alpha1.FAILING_STATUS, "persisting"),
+		tab("blocking#kind", v1alpha1.FLAKY_STATUS, "was passing"),
+		tab("release#gce", v1alpha1.FAILING_STATUS, "not fetched before"),
+	}
+
+	entries := Compare(
+		Side{Dashboards: []string{"blocking", "informing"}, Tabs: before},
+		Side{Dashboards: []string{"blocking", "release"}, Tabs: after},
+	)
+	assert.Equal(t, []Entry{
+		{Change: New, BoardHash: "blocking#kind", TestName: "was passing", After: v1alpha1.FLAKY_STATUS, Test: after[1].TestRuns[0]},
+		{Change: StillFailing, BoardHash: "blocking#gce", TestName: "persisting", Before: v1alpha1.FAILING_STATUS, After: v1alpha1.FAILING_STATUS, Test: after[0].TestRuns[0]},
+		{Change: Uncompared, BoardHash: "informing#kind", TestName: "not fetched after", Before: v1alpha1.FAILING_STATUS, Test: before[1].TestRuns[0]},
+		{Change: Uncompared, BoardHash: "release#gce", TestName: "not fetched before", After: v1alpha1.FAILING_STATUS, Test: after[2].TestRuns[0]},
+	}, entries)
+
+	var text bytes.Buffer
+	assert.NoError(t, Write(&text, entries, FormatText))
+	assert.Contains(t, text.String(), `Not compared, the board is missing on one side (2)
+  informing#kind: not fetched after (missing after)
+  release#gce: not fetched before (missing before)
+`)
+}
+
+func TestCompareGreenBoard(t *testing.T) {
+	before := []*v1alpha1.DashboardTab{
+		tab("blocking#gce", v1alpha1.FAILING_STATUS, "persisting"),
+		tab("informing#kind", v1alpha1.FAILING_STATUS, "fixed"),
+	}
+	after := []*v1alpha1.DashboardTab{
+		tab("blocking#gce", v1alpha1.FAILING_STATUS, "persisting"),
+	}
+
+	// the informing board was scraped after, its tabs all passed
+	entries := Compare(
+		Side{Dashboards: []string{"blocking", "informing"}, Tabs: before},
+		Side{Dashboards: []string{"blocking", "informing"}, Tabs: after},
+	)
+	assert.Equal(t, []Entry{
+		{Change: StillFailing, BoardHash: "blocking#gce", TestName: "persisting", Before: v1alpha1.FAILING_STATUS, After: v1alpha1.FAILING_STATUS, Test: after[0].TestRuns[0]},
+		{Change: Resolved, BoardHash: "informing#kind", TestName: "fixed", Before: v1alpha1.FAILING_STATUS, Test: before[1].TestRuns[0]},
+	}, entries)
+}
+
+func TestWrite(t *testing.T) {
+	entries := Compare(
+		Side{Tabs: []*v1alpha1.DashboardTab{tab("blocking#gce", v1alpha1.FLAKY_STATUS, "escalated")}},
+		Side{Tabs: []*v1alpha1.DashboardTab{tab("blocking#gce", v1alpha1.FAILING_STATUS, "escalated", "brand new")}},
+	)
+
+	var text bytes.Buffer
+	assert.NoError(t, Write(&text, entries, FormatText))
+	assert.Equal(t, `New failures (1)
+  blocking#gce: brand new
+
+Escalated from flaky to failing (1)
+  blocking#gce: escalated (FLAKY -> FAILING)
+`, text.String())
+
+	var markdown bytes.Buffer
+	assert.NoError(t, Write(&markdown, entries, FormatMarkdown))
+	assert.Equal(t, "### New failures (1)\n\n"+
+		"* `brand new` on [blocking#gce](https://prow/brand new)\n\n"+
+		"### Escalated from flaky to failing (1)\n\n"+
+		"* `escalated` on [blocking#gce](https://prow/escalated) (FLAKY -> FAILING)\n", markdown.String())
+
+	var output bytes.Buffer
+	assert.NoError(t, Write(&output, entries, FormatJSON))
+	var decoded []Entry
+	assert.NoError(t, json.Unmarshal(output.Bytes(), &decoded))
+	assert.Equal(t, entries, decoded)
+
+	var empty bytes.Buffer
+	assert.NoError(t, Write(&empty, nil, FormatText))
+	assert.Equal(t, "No changes\n", empty.String())
+
+	assert.Error(t, Write(&empty, nil, "yaml"))
+}
@@ -0,0 +1,91 @@
+package diff
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Output formats supported by Write.
+const (
+	FormatText     = "text"
+	FormatMarkdown = "markdown"
+	FormatJSON     = "json"
+)
+
+// Formats lists the output formats supported by Write.
+var Formats = []string{FormatText, FormatMarkdown, FormatJSON}
+
+// sectionTitles are the headings of each change in the text and Markdown output.
+var sectionTitles = map[Change]string{
+	New:          "New failures",
+	Escalated:    "Escalated from flaky to failing",
+	StillFailing: "Still failing",
+	Resolved:     "Resolved",
+	Uncompared:   "Not compared, the board is missing on one side",
+}
+
+// Write prints the entries in the given format.
+func Write(w io.Writer, entries []Entry, format string) error {
+	switch format {
+	case FormatJSON:
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		if entries == nil {
+			entries = []Entry{}
+		}
+		return encoder.Encode(entries)
+	case FormatText, FormatMarkdown:
+		_, err := io.WriteString(w, render(entries, format == FormatMarkdown))
+		return err
+	}
+	return fmt.Errorf("unknown output format %q, valid formats are %v", format, Formats)
+}
+
+func render(entries []Entry, markdown bool) string {
+	var out strings.Builder
+	counts := Count(entries)
+	for _, change := range []Change{New, Escalated, StillFailing, Resolved, Uncompared} {
+		if counts[change] == 0 {
+			continue
+		}
+		if out.Len() > 0 {
+			out.WriteString("\n")
+		}
+		if markdown {
+			fmt.Fprintf(&out, "### %s (%d)\n\n", sectionTitles[change], counts[change])
+		} else {
+			fmt.Fprintf(&out, "%s (%d)\n", sectionTitles[change], counts[change])
+		}
+		for _, entry := range entries {
+			if entry.Change != change {
+				continue
+			}
+			if markdown {
+				fmt.Fprintf(&out, "* `%s` on [%s](%s)%s\n", entry.TestName, entry.BoardHash, entry.Test.ProwJobURL, transition(entry))
+			} else {
+				fmt.Fprintf(&out, "  %s: %s%s\n", entry.BoardHash, entry.TestName, transition(entry))
+			}
+		}
+	}
+	if out.Len() == 0 {
+		out.WriteString("No changes\n")
+	}
+	return out.String()
+}
+
+// transition shows the state change of tests present in both sets, or the
+// side missing the board of an uncompared test.
+func transition(entry Entry) string {
+	if entry.Change == Uncompared {
+		if entry.Before == "" {
+			return " (missing before)"
+		}
+		return " (missing after)"
+	}
+	if entry.Before == "" || entry.After == "" || entry.Before == entry.After {
+		return ""
+	}
+	return fmt.Sprintf(" (%s -> %s)", entry.Before, entry.After)
+}
@@ -0,0 +1,102 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	g4 "github.com/shurcooL/githubv4"
+)
+
+const (
+	// maxRangeCommits caps the commits walked back from the first failure.
+	maxRangeCommits = 100
+)
+
+// PullRequest is a pull request merged in a suspect commit range.
+type PullRequest struct {
+	Number int
+	Title  string
+	URL    string
+}
+
+// CompareURL returns the GitHub link comparing the last green commit with
+// the first failing one in the owner/name repository, empty when either is unknown.
+func CompareURL(repository, base, head string) string {
+	if base == "" || head == "" {
+		return ""
+	}
+	if repository == "" {
+		repository = DefaultRepository
+	}
+	return fmt.Sprintf("https://github.com/%s/compare/%s...%s", repository, base, head)
+}
+
+// ListMergedPullRequests returns the pull requests merged after the base commit
+// up to and including the head commit, newest first. At most the last 100
+// commits of head are inspected, truncated reports the base was not among them
+// and older pull requests of the range are missing.
+func (g *ProjectManager) ListMergedPullRequests(base, head string) (pullRequests []PullRequest, truncated bool, err error) {
+	if g.githubClient == nil {
+		return nil, false, errors.New("github GraphQL client is nil")
+	}
+	owner, name, err := splitRepository(g.repository)
+	if err != nil {
+		return nil, false, err
+	}
+
+	var query struct {
+		Repository struct {
+			Object struct {
+				Commit struct {
+					History struct {
+						Nodes []struct {
+							Oid                    g4.GitObjectID
+							AssociatedPullRequests struct {
+								Nodes []struct {
+									Number g4.Int
+									Title  g4.String
+									URL    g4.String
+									Merged g4.Boolean
+								}
+							} `graphql:"associatedPullRequests(first: 1)"`
+						}
+					} `graphql:"history(first: $first)"`
+				} `graphql:"... on Commit"`
+			} `graphql:"object(expression: $head)"`
+		} `graphql:"repository(owner: $owner, name: $name)"`
+	}
+
+	variables := map[string]interface{}{
+		"owner": g4.String(owner),
+		"name":  g4.String(name),
+		"head":  g4.String(head),
+		"first": g4.Int(maxRangeCommits),
+	}
+
+	if err := g.githubClient.Query(context.Background(), &query, variables); err != nil {
+		return nil, false, fmt.Errorf("failed to query commit history: %w", err)
+	}
+
+	seen := map[int]bool{}
+	truncated = true
+	for _, commit := range query.Repository.Object.Commit.History.Nodes {
+		if strings.HasPrefix(string(commit.Oid), base) {
+			truncated = false
+			break
+		}
+		for _, pr := range commit.AssociatedPullRequests.Nodes {
+			if !bool(pr.Merged) || seen[int(pr.Number)] {
+				continue
+			}
+			seen[int(pr.Number)] = true
+			pullRequests = append(pullRequests, PullRequest{
+				Number: int(pr.Number),
+				Title:  string(pr.Title),
+				URL:    string(pr.URL),
+			})
+		}
+	}
+	return pullRequests, truncated, nil
+}
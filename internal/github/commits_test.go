@@ -0,0 +1,69 @@
+package github
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	g4 "github.com/shurcooL/githubv4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompareURL(t *testing.T) {
+	assert.Equal(t, "https://github.com/kubernetes/kubernetes/compare/abc...def", CompareURL("", "abc", "def"))
+	assert.Equal(t, "https://github.com/example/kubernetes/compare/abc...def", CompareURL("example/kubernetes", "abc", "def"))
+	assert.Empty(t, CompareURL(DefaultRepository, "", "def"))
+}
+
+func TestListMergedPullRequests(t *testing.T) {
+	// commit i is associated with the merged pull request 100+i
+	commits := func(count int) string {
+		nodes := make([]string, 0, count)
+		for i := range count {
+			nodes = append(nodes, fmt.Sprintf(`{"oid": "%d%039d", "associatedPullRequests": {"nodes": [
+				{"number": %d, "title": "PR %d", "url": "https://github.com/kubernetes/kubernetes/pull/%d", "merged": true}
+			]}}`, i, 0, 100+i, 100+i, 100+i))
+		}
+		return `{"data": {"repository": {"object": {"history": {"nodes": [` + strings.Join(nodes, ",") + `]}}}}}`
+	}
+
+	tests := []struct {
+		name              string
+		base              string
+		expectedNumbers   []int
+		expectedTruncated bool
+	}{
+		{
+			name:            "range ending at the base",
+			base:            "200000000000",
+			expectedNumbers: []int{100, 101},
+		},
+		{
+			name:              "base older than the inspected commits",
+			base:              "ffffffffffff",
+			expectedNumbers:   []int{100, 101, 102, 103},
+			expectedTruncated: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Write([]byte(commits(4))) // nolint
+			}))
+			defer server.Close()
+
+			g := &ProjectManager{repository: DefaultRepository, githubClient: g4.NewEnterpriseClient(server.URL, server.Client())}
+			pullRequests, truncated, err := g.ListMergedPullRequests(tt.base, "head")
+			assert.NoError(t, err)
+			var numbers []int
+			for _, pr := range pullRequests {
+				numbers = append(numbers, pr.Number)
+			}
+			assert.Equal(t, tt.expectedNumbers, numbers)
+			assert.Equal(t, tt.expectedTruncated, truncated)
+		})
+	}
+}
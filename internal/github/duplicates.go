@@ -0,0 +1,263 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	g4 "github.com/shurcooL/githubv4"
+
+	"sigs.k8s.io/signalhound/internal/truncate"
+)
+
+const (
+	// maxSearchResults caps the open issues returned for each searched term.
+	maxSearchResults = 10
+
+	// maxSearchTermLength keeps the search query under the GitHub limit of 256 characters.
+	maxSearchTermLength = 180
+
+	// maxProjectItems caps the project items scanned for duplicates, the newest first.
+	maxProjectItems = 1000
+
+	// statusFieldName is the project field holding the triage status of the items.
+	statusFieldName = "Status"
+)
+
+// Match is an open issue or a project item already tracking a test.
+type Match struct {
+	// ID is the node ID of the issue, empty for draft items that cannot be commented on.
+	ID g4.ID
+	// ItemID is the node ID of the project item, set for the drafts to convert them.
+	ItemID g4.ID
+	Number int
+	Title  string
+	URL    string
+	// Status is the value of the project Status field, empty when the issue is not on the board.
+	Status string
+	Draft  bool
+}
+
+// FindDuplicates searches the open issues of the repository of the options,
+// where a new issue would be filed, and the items of the project mentioning any
+// of the terms, usually the test and job names. Issues found in both places are
+// returned once with their project status.
+func (g *ProjectManager) FindDuplicates(opts IssueOptions, terms ...string) ([]Match, error) {
+	if g.githubClient == nil {
+		return nil, errors.New("github GraphQL client is nil")
+	}
+	owner, name, err := opts.repository(g.repository)
+	if err != nil {
+		return nil, err
+	}
+	terms = searchTerms(terms)
+	if len(terms) == 0 {
+		return nil, nil
+	}
+
+	var matches []Match
+	byURL := map[string]int{}
+	add := func(match Match) {
+		if i, ok := byURL[match.URL]; ok && match.URL != "" {
+			if match.Status != "" {
+				matches[i].Status = match.Status
+			}
+			return
+		}
+		if match.URL != "" {
+			byURL[match.URL] = len(matches)
+		}
+		matches = append(matches, match)
+	}
+
+	for _, term := range terms {
+		issues, err := g.searchIssues(owner, name, term)
+		if err != nil {
+			return nil, err
+		}
+		for _, issue := range issues {
+			add(issue)
+		}
+	}
+	items, err := g.searchProjectItems(terms)
+	if err != nil {
+		return nil, err
+	}
+	for _, item := range items {
+		add(item)
+	}
+	return matches, nil
+}
+
+// AddComment comments on the issue with the given node ID.
+func (g *ProjectManager) AddComment(issueID g4.ID, body string) error {
+	if g.githubClient == nil {
+		return errors.New("github GraphQL client is nil")
+	}
+	var mutation struct {
+		AddComment struct {
+			ClientMutationID string
+		} `graphql:"addComment(input: $input)"`
+	}
+	input := g4.AddCommentInput{SubjectID: issueID, Body: g4.String(body)}
+	if err := g.githubClient.Mutate(context.Background(), &mutation, input, nil); err != nil {
+		return fmt.Errorf("failed to comment on the issue: %w", err)
+	}
+	return nil
+}
+
+// searchIssues returns the open issues of the repository mentioning the term,
+// quoted as is since searchTerms already removed its quotes.
+func (g *ProjectManager) searchIssues(owner, name, term string) ([]Match, error) {
+	var query struct {
+		Search struct {
+			Nodes []struct {
+				Issue struct {
+					ID     g4.ID
+					Number g4.Int
+					Title  g4.String
+					URL    g4.String
+				} `graphql:"... on Issue"`
+			}
+		} `graphql:"search(query: $query, type: ISSUE, first: $first)"`
+	}
+
+	variables := map[string]interface{}{
+		"query": g4.String(fmt.Sprintf(`repo:%s/%s is:issue is:open "%s"`, owner, name, term)),
+		"first": g4.Int(maxSearchResults),
+	}
+
+	if err := g.githubClient.Query(context.Background(), &query, variables); err != nil {
+		return nil, fmt.Errorf("failed to search issues: %w", err)
+	}
+
+	matches := make([]Match, 0, len(query.Search.Nodes))
+	for _, node := range query.Search.Nodes {
+		matches = append(matches, Match{
+			ID:     node.Issue.ID,
+			Number: int(node.Issue.Number),
+			Title:  string(node.Issue.Title),
+			URL:    string(node.Issue.URL),
+		})
+	}
+	return matches, nil
+}
+
+// searchProjectItems returns the open issues and drafts of the project whose
+// title or body mentions any of the terms, archived items are ignored. The
+// pages are read backwards, so a large board loses its oldest items to the cap.
+func (g *ProjectManager) searchProjectItems(terms []string) ([]Match, error) {
+	if err := g.resolveProject(); err != nil {
+		return nil, err
+	}
+	var query struct {
+		Node struct {
+			ProjectV2 struct {
+				Items struct {
+					PageInfo struct {
+						HasPreviousPage g4.Boolean
+						StartCursor     g4.String
+					}
+					Nodes []struct {
+						ID         g4.ID
+						IsArchived g4.Boolean
+						Status     struct {
+							ProjectV2ItemFieldSingleSelectValue struct {
+								Name g4.String
+							} `graphql:"... on ProjectV2ItemFieldSingleSelectValue"`
+						} `graphql:"fieldValueByName(name: $statusField)"`
+						Content struct {
+							Typename   string `graphql:"__typename"`
+							DraftIssue struct {
+								Title g4.String
+								Body  g4.String
+							} `graphql:"... on DraftIssue"`
+							Issue struct {
+								ID     g4.ID
+								Number g4.Int
+								Title  g4.String
+								Body   g4.String
+								URL    g4.String
+								State  g4.IssueState
+							} `graphql:"... on Issue"`
+						}
+					}
+				} `graphql:"items(last: 100, before: $cursor)"`
+			} `graphql:"... on ProjectV2"`
+		} `graphql:"node(id: $projectID)"`
+	}
+
+	variables := map[string]interface{}{
+		"projectID":   g4.ID(g.projectID),
+		"statusField": g4.String(statusFieldName),
+		"cursor":      (*g4.String)(nil),
+	}
+
+	var matches []Match
+	for scanned := 0; scanned < maxProjectItems; {
+		if err := g.githubClient.Query(context.Background(), &query, variables); err != nil {
+			return nil, fmt.Errorf("failed to query project items: %w", err)
+		}
+		items := query.Node.ProjectV2.Items
+		for _, item := range items.Nodes {
+			if bool(item.IsArchived) {
+				continue
+			}
+			status := string(item.Status.ProjectV2ItemFieldSingleSelectValue.Name)
+			switch item.Content.Typename {
+			case "DraftIssue":
+				draft := item.Content.DraftIssue
+				if mentionsAny(string(draft.Title)+"\n"+string(draft.Body), terms) {
+					matches = append(matches, Match{ItemID: item.ID, Title: string(draft.Title), Status: status, Draft: true})
+				}
+			case "Issue":
+				issue := item.Content.Issue
+				if issue.State == g4.IssueStateOpen && mentionsAny(string(issue.Title)+"\n"+string(issue.Body), terms) {
+					matches = append(matches, Match{
+						ID:     issue.ID,
+						Number: int(issue.Number),
+						Title:  string(issue.Title),
+						URL:    string(issue.URL),
+						Status: status,
+					})
+				}
+			}
+		}
+		scanned += len(items.Nodes)
+		if !bool(items.PageInfo.HasPreviousPage) {
+			break
+		}
+		cursor := items.PageInfo.StartCursor
+		variables["cursor"] = &cursor
+	}
+	return matches, nil
+}
+
+// searchTerms drops the empty and repeated terms, and shortens the long test
+// names to fit in a search query.
+func searchTerms(terms []string) []string {
+	var cleaned []string
+	seen := map[string]bool{}
+	for _, term := range terms {
+		term = strings.TrimSpace(strings.ReplaceAll(term, `"`, ""))
+		term = strings.TrimSpace(truncate.Head(term, maxSearchTermLength))
+		if term == "" || seen[term] {
+			continue
+		}
+		seen[term] = true
+		cleaned = append(cleaned, term)
+	}
+	return cleaned
+}
+
+// mentionsAny reports whether the text contains any of the terms, ignoring case.
+func mentionsAny(text string, terms []string) bool {
+	text = strings.ToLower(text)
+	for _, term := range terms {
+		if strings.Contains(text, strings.ToLower(term)) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,121 @@
+package github
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	g4 "github.com/shurcooL/githubv4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSearchTerms(t *testing.T) {
+	long := "[sig-node] " + strings.Repeat("é", maxSearchTermLength)
+
+	tests := []struct {
+		name     string
+		terms    []string
+		expected []string
+	}{
+		{
+			name:     "empty and repeated terms are dropped",
+			terms:    []string{"", "  ", "ci-kubernetes-e2e", "ci-kubernetes-e2e "},
+			expected: []string{"ci-kubernetes-e2e"},
+		},
+		{
+			name:     "quotes are removed",
+			terms:    []string{`Pods should be "ready"`},
+			expected: []string{"Pods should be ready"},
+		},
+		{
+			name:     "long terms are shortened on rune boundaries",
+			terms:    []string{long},
+			expected: []string{long[:maxSearchTermLength-1]},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			terms := searchTerms(tt.terms)
+			assert.Equal(t, tt.expected, terms)
+			for _, term := range terms {
+				assert.True(t, utf8.ValidString(term))
+			}
+		})
+	}
+}
+
+func TestMentionsAny(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		terms    []string
+		expected bool
+	}{
+		{name: "term in the text", text: "Failing test: Pods should be ready", terms: []string{"pods should"}, expected: true},
+		{name: "any of the terms", text: "ci-kubernetes-e2e-gci-gce", terms: []string{"kind", "gci-gce"}, expected: true},
+		{name: "no term in the text", text: "Failing test: Pods should be ready", terms: []string{"volumes"}},
+		{name: "no terms", text: "Failing test"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, mentionsAny(tt.text, tt.terms))
+		})
+	}
+}
+
+func TestFindDuplicates(t *testing.T) {
+	const issueURL = "https://github.com/kubernetes/kubernetes/issues/1"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var request struct {
+			Query     string
+			Variables map[string]interface{}
+		}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+
+		var response string
+		switch {
+		case strings.Contains(request.Query, "search("):
+			assert.Equal(t, `repo:kubernetes/test-infra is:issue is:open "Pods should be ready"`, request.Variables["query"])
+			response = `{"data": {"search": {"nodes": [
+				{"id": "I_1", "number": 1, "title": "Pods should be ready", "url": "` + issueURL + `"}
+			]}}}`
+		case strings.Contains(request.Query, "node("):
+			response = `{"data": {"node": {"items": {
+				"pageInfo": {"hasPreviousPage": false, "startCursor": ""},
+				"nodes": [
+					{"id": "PVTI_1", "isArchived": false, "fieldValueByName": {"name": "Drafting"}, "content": {
+						"__typename": "Issue", "id": "I_1", "number": 1, "title": "Pods should be ready",
+						"body": "", "url": "` + issueURL + `", "state": "OPEN"}},
+					{"id": "PVTI_2", "isArchived": false, "fieldValueByName": {"name": "Drafting"}, "content": {
+						"__typename": "DraftIssue", "title": "Failing test", "body": "Pods should be ready"}},
+					{"id": "PVTI_3", "isArchived": true, "fieldValueByName": {"name": "Drafting"}, "content": {
+						"__typename": "DraftIssue", "title": "Pods should be ready", "body": ""}},
+					{"id": "PVTI_4", "isArchived": false, "fieldValueByName": {"name": "Drafting"}, "content": {
+						"__typename": "DraftIssue", "title": "Volumes should mount", "body": ""}}
+				]
+			}}}}`
+		default:
+			t.Errorf("unexpected query %s", request.Query)
+		}
+		w.Write([]byte(response)) // nolint
+	}))
+	defer server.Close()
+
+	g := &ProjectManager{
+		organization: DefaultOrganization,
+		repository:   DefaultRepository,
+		projectID:    DefaultProjectID,
+		githubClient: g4.NewEnterpriseClient(server.URL, server.Client()),
+	}
+	matches, err := g.FindDuplicates(IssueOptions{Repository: "kubernetes/test-infra"}, "Pods should be ready")
+	assert.NoError(t, err)
+	assert.Equal(t, []Match{
+		{ID: "I_1", Number: 1, Title: "Pods should be ready", URL: issueURL, Status: "Drafting"},
+		{ItemID: "PVTI_2", Title: "Failing test", Status: "Drafting", Draft: true},
+	}, matches)
+}
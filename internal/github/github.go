@@ -2,7 +2,6 @@ package github
 
 import (
 	"context"
-	"errors"
 	"fmt"
 	"regexp"
 	"strconv"
@@ -13,13 +12,21 @@ import (
 )
 
 const (
-	PROJECT_ID   = "PVT_kwDOAM_34M4AAThW"
-	ORGANIZATION = "kubernetes"
+	// DefaultProjectID is the node ID of the CI signal project of the kubernetes organization.
+	DefaultProjectID    = "PVT_kwDOAM_34M4AAThW"
+	DefaultOrganization = "kubernetes"
+	// DefaultRepository is the owner/name of the code repository of the tests.
+	DefaultRepository = "kubernetes/kubernetes"
 )
 
 type ProjectManagerInterface interface {
 	GetProjectFields() ([]ProjectFieldInfo, error)
 	CreateDraftIssue(title, body, board string) error
+	ListMergedPullRequests(base, head string) ([]PullRequest, bool, error)
+	FindDuplicates(opts IssueOptions, terms ...string) ([]Match, error)
+	AddComment(issueID g4.ID, body string) error
+	CreateIssue(title, body, board string, opts IssueOptions) (*Issue, error)
+	ConvertDraftIssue(itemID g4.ID, opts IssueOptions) (*Issue, error)
 }
 
 // ProjectManager represents a GitHub organization with a global workflow file and reference
@@ -27,9 +34,18 @@ type ProjectManager struct {
 	// organization is the GitHub organization name
 	organization string
 
+	// repository is the owner/name of the code repository, independent of the organization
+	repository string
+
+	// projectNumber is the number of the project in the organization, resolved to projectID
+	projectNumber int
+
 	// projectID is the ID of the Kubernetes version project board
 	projectID string
 
+	// fieldOptions maps the project field names to the options set on the new items
+	fieldOptions map[string]string
+
 	// fields is a map of project field names to their IDs
 	fields map[string]ProjectFieldInfo
 
@@ -44,22 +60,46 @@ type ProjectFieldInfo struct {
 	Options map[string]interface{} // option name -> option ID
 }
 
+// Option configures the ProjectManager.
+type Option func(*ProjectManager)
+
+// WithProject uses the organization, project number, repository and field
+// mapping of the config, the project node ID is resolved on the first request.
+func WithProject(cfg ProjectConfig) Option {
+	return func(g *ProjectManager) {
+		cfg = cfg.WithDefaults()
+		g.organization = cfg.Organization
+		g.repository = cfg.Repository
+		g.fieldOptions = cfg.Fields
+		if cfg.ProjectNumber != 0 {
+			g.projectNumber = cfg.ProjectNumber
+			g.projectID = ""
+		}
+	}
+}
+
 // NewProjectManager creates a new ProjectManager
-func NewProjectManager(ctx context.Context, token string) ProjectManagerInterface {
-	return &ProjectManager{
-		organization: ORGANIZATION,
-		projectID:    PROJECT_ID,
+func NewProjectManager(ctx context.Context, token string, opts ...Option) ProjectManagerInterface {
+	g := &ProjectManager{
+		organization: DefaultOrganization,
+		repository:   DefaultRepository,
+		projectID:    DefaultProjectID,
+		fieldOptions: DefaultFields,
 		fields:       map[string]ProjectFieldInfo{},
 		githubClient: g4.NewClient(oauth2.NewClient(
 			ctx, oauth2.StaticTokenSource(&oauth2.Token{AccessToken: token}),
 		)),
 	}
+	for _, opt := range opts {
+		opt(g)
+	}
+	return g
 }
 
 // GetProjectFields queries the project fields and their options
 func (g *ProjectManager) GetProjectFields() ([]ProjectFieldInfo, error) {
-	if g.githubClient == nil {
-		return nil, errors.New("github GraphQL client is nil")
+	if err := g.resolveProject(); err != nil {
+		return nil, err
 	}
 
 	var query struct {
@@ -131,78 +171,14 @@ func (g *ProjectManager) GetProjectFields() ([]ProjectFieldInfo, error) {
 // CreateDraftIssue creates a new issue draft issue in the board with a
 // specific test issue template.
 func (g *ProjectManager) CreateDraftIssue(title, body, board string) error {
-	if g.githubClient == nil {
-		return errors.New("github GraphQL client is nil")
+	if err := g.resolveProject(); err != nil {
+		return err
 	}
 
 	// first, get the project fields to find the correct field IDs and option IDs
-	fields, err := g.GetProjectFields()
+	fieldValues, err := g.projectFieldValues(board)
 	if err != nil {
-		return fmt.Errorf("failed to get project fields: %w", err)
-	}
-
-	// find the fields we need
-	var k8sReleaseFieldID, viewFieldID, statusFieldID, boardFieldID g4.ID
-	var k8sReleaseValueID, viewValueID, statusValueID, boardValueID g4.ID
-
-	for _, field := range fields {
-		fieldNameLower := strings.ToLower(string(field.Name))
-
-		// find K8s Release field - look for fields containing "k8s", "release", or "version"
-		if strings.Contains(fieldNameLower, "k8s release") {
-			k8sReleaseFieldID = field.ID
-			// find the latest version option (highest version number)
-			latestVersion := ""
-			latestVersionID := g4.ID("")
-			for optName, optID := range field.Options {
-				// extract version number from option name (e.g., "v1.32" -> "1.32")
-				if version := extractVersion(optName); version != "" {
-					if latestVersion == "" || compareVersions(version, latestVersion) > 0 {
-						latestVersion = version
-						latestVersionID = optID
-					}
-				}
-			}
-			if latestVersionID != g4.ID("") {
-				k8sReleaseValueID = latestVersionID
-			}
-		}
-
-		// find view field - look for fields containing "view"
-		if strings.Contains(fieldNameLower, "view") {
-			viewFieldID = field.ID
-			// find "issue-tracking" option
-			for optName, optID := range field.Options {
-				if strings.Contains(strings.ToLower(optName), "issue-tracking") ||
-					strings.Contains(strings.ToLower(optName), "issue tracking") {
-					viewValueID = optID
-					break
-				}
-			}
-		}
-
-		// find the board field, master-informing or master-blocking
-		if strings.Contains(fieldNameLower, "board") {
-			boardFieldID = field.ID
-			for optName, optID := range field.Options {
-				if strings.Contains(board, strings.ToLower(optName)) {
-					boardValueID = optID
-					break
-				}
-			}
-		}
-
-		// find Status field
-		if strings.Contains(fieldNameLower, "status") {
-			statusFieldID = field.ID
-			for optName, optID := range field.Options {
-				if strings.Contains(strings.ToLower(optName), "drafting") ||
-					strings.Contains(strings.ToLower(optName), "draft") {
-					statusValueID = optID
-					break
-				}
-			}
-		}
+		return err
 	}
 
 	// create the draft issue
@@ -224,25 +200,49 @@ func (g *ProjectManager) CreateDraftIssue(title, body, board string) error {
 		return fmt.Errorf("failed to create draft issue: %w", err)
 	}
 
-	itemID := mutationDraft.AddProjectV2DraftIssue.ProjectItem.ID
+	g.updateProjectFields(mutationDraft.AddProjectV2DraftIssue.ProjectItem.ID, fieldValues)
+	return nil
+}
+
+// projectFieldValue is the option set on a single select field of the new project items.
+type projectFieldValue struct {
+	fieldID   g4.ID
+	optionID  g4.ID
+	fieldName string
+}
+
+// projectFieldValues returns the options of the mapped project fields, e.g.
+// K8s Release, View, Status and Board, for the items created for the board.
+func (g *ProjectManager) projectFieldValues(board string) ([]projectFieldValue, error) {
+	fields, err := g.GetProjectFields()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get project fields: %w", err)
+	}
+
+	// set the mapped option of the fields we need, skipping the options not found
+	var fieldValues []projectFieldValue
+	for _, field := range fields {
+		desired, ok := g.fieldOption(string(field.Name))
+		if !ok {
+			continue
+		}
+		if optionID := selectOption(field.Options, desired, board); optionID != nil {
+			fieldValues = append(fieldValues, projectFieldValue{field.ID, optionID, string(field.Name)})
+		}
+	}
+	return fieldValues, nil
+}
+
+// updateProjectFields sets the field options of the project item, a field
+// that cannot be updated is only reported.
+func (g *ProjectManager) updateProjectFields(itemID g4.ID, fieldValues []projectFieldValue) {
 	var mutationUpdate struct {
 		UpdateProjectV2ItemFieldValue struct {
 			ClientMutationID string
 		} `graphql:"updateProjectV2ItemFieldValue(input: $input)"`
 	}
 
-	fieldUpdates := []struct {
-		fieldID   g4.ID
-		optionID  g4.ID
-		fieldName string
-	}{
-		{k8sReleaseFieldID, k8sReleaseValueID, "K8s Release"},
-		{viewFieldID, viewValueID, "View"},
-		{statusFieldID, statusValueID, "Status"},
-		{boardFieldID, boardValueID, "Testgrid Board"},
-	}
-
-	for _, update := range fieldUpdates {
+	for _, update := range fieldValues {
 		if update.fieldID != "" && update.optionID != "" {
 			optionIDStr := fmt.Sprintf("%s", update.optionID)
 			if err := g.githubClient.Mutate(context.Background(), &mutationUpdate, g4.UpdateProjectV2ItemFieldValueInput{
@@ -255,7 +255,6 @@ func (g *ProjectManager) CreateDraftIssue(title, body, board string) error {
 			}
 		}
 	}
-	return nil
 }
 
 // extractVersion extracts a version string from text (e.g., "v1.32" -> "1.32", "1.30" -> "1.30")
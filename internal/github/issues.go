@@ -0,0 +1,221 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	g4 "github.com/shurcooL/githubv4"
+)
+
+// IssueOptions configures the repository issues filed for the tests.
+type IssueOptions struct {
+	// Repository is the owner/name of the repository, the code repository of the project config by default.
+	Repository string
+	// Labels are the names of the labels, e.g. kind/flake and sig/node.
+	Labels []string
+	// Milestone is the title of an open milestone, none when empty.
+	Milestone string
+}
+
+// Issue is a repository issue filed for a test.
+type Issue struct {
+	ID     g4.ID
+	Number int
+	URL    string
+}
+
+// repository returns the owner and name of the repository of the issues.
+func (o IssueOptions) repository(defaultRepository string) (owner, name string, err error) {
+	if o.Repository == "" {
+		return splitRepository(defaultRepository)
+	}
+	return splitRepository(o.Repository)
+}
+
+// issueMetadata are the node IDs of the repository, labels and milestone of the issues.
+type issueMetadata struct {
+	repositoryID g4.ID
+	labelIDs     []g4.ID
+	milestoneID  *g4.ID
+}
+
+// CreateIssue creates an issue in the repository with the labels and the
+// milestone of the options, and adds it to the project with the same fields
+// as CreateDraftIssue.
+func (g *ProjectManager) CreateIssue(title, body, board string, opts IssueOptions) (*Issue, error) {
+	if g.githubClient == nil {
+		return nil, errors.New("github GraphQL client is nil")
+	}
+	fieldValues, err := g.projectFieldValues(board)
+	if err != nil {
+		return nil, err
+	}
+	metadata, err := g.issueMetadata(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	var mutationIssue struct {
+		CreateIssue struct {
+			Issue struct {
+				ID     g4.ID
+				Number g4.Int
+				URL    g4.String
+			}
+		} `graphql:"createIssue(input: $input)"`
+	}
+	bodyInput := g4.String(body)
+	inputIssue := g4.CreateIssueInput{
+		RepositoryID: metadata.repositoryID,
+		Title:        g4.String(title),
+		Body:         &bodyInput,
+		MilestoneID:  metadata.milestoneID,
+	}
+	if len(metadata.labelIDs) > 0 {
+		inputIssue.LabelIDs = &metadata.labelIDs
+	}
+	if err := g.githubClient.Mutate(context.Background(), &mutationIssue, inputIssue, nil); err != nil {
+		return nil, fmt.Errorf("failed to create issue: %w", err)
+	}
+	issue := &Issue{
+		ID:     mutationIssue.CreateIssue.Issue.ID,
+		Number: int(mutationIssue.CreateIssue.Issue.Number),
+		URL:    string(mutationIssue.CreateIssue.Issue.URL),
+	}
+
+	var mutationItem struct {
+		AddProjectV2ItemByID struct {
+			Item struct {
+				ID g4.ID
+			}
+		} `graphql:"addProjectV2ItemById(input: $input)"`
+	}
+	inputItem := g4.AddProjectV2ItemByIdInput{ProjectID: g4.ID(g.projectID), ContentID: issue.ID}
+	if err := g.githubClient.Mutate(context.Background(), &mutationItem, inputItem, nil); err != nil {
+		return issue, fmt.Errorf("failed to add issue #%d to the project: %w", issue.Number, err)
+	}
+
+	g.updateProjectFields(mutationItem.AddProjectV2ItemByID.Item.ID, fieldValues)
+	return issue, nil
+}
+
+// ConvertDraftIssue converts the draft item of the project to an issue of the
+// repository, then applies the labels and the milestone. The project fields of
+// the item are kept, the triage may already have moved its status.
+func (g *ProjectManager) ConvertDraftIssue(itemID g4.ID, opts IssueOptions) (*Issue, error) {
+	if g.githubClient == nil {
+		return nil, errors.New("github GraphQL client is nil")
+	}
+	metadata, err := g.issueMetadata(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	var mutationConvert struct {
+		ConvertProjectV2DraftIssueItemToIssue struct {
+			Item struct {
+				ID      g4.ID
+				Content struct {
+					Issue struct {
+						ID     g4.ID
+						Number g4.Int
+						URL    g4.String
+					} `graphql:"... on Issue"`
+				}
+			}
+		} `graphql:"convertProjectV2DraftIssueItemToIssue(input: $input)"`
+	}
+	inputConvert := g4.ConvertProjectV2DraftIssueItemToIssueInput{ItemID: itemID, RepositoryID: metadata.repositoryID}
+	if err := g.githubClient.Mutate(context.Background(), &mutationConvert, inputConvert, nil); err != nil {
+		return nil, fmt.Errorf("failed to convert draft issue: %w", err)
+	}
+	item := mutationConvert.ConvertProjectV2DraftIssueItemToIssue.Item
+	issue := &Issue{
+		ID:     item.Content.Issue.ID,
+		Number: int(item.Content.Issue.Number),
+		URL:    string(item.Content.Issue.URL),
+	}
+
+	if len(metadata.labelIDs) > 0 || metadata.milestoneID != nil {
+		var mutationUpdate struct {
+			UpdateIssue struct {
+				ClientMutationID string
+			} `graphql:"updateIssue(input: $input)"`
+		}
+		inputUpdate := g4.UpdateIssueInput{ID: issue.ID, MilestoneID: metadata.milestoneID}
+		if len(metadata.labelIDs) > 0 {
+			inputUpdate.LabelIDs = &metadata.labelIDs
+		}
+		if err := g.githubClient.Mutate(context.Background(), &mutationUpdate, inputUpdate, nil); err != nil {
+			return issue, fmt.Errorf("failed to label issue #%d: %w", issue.Number, err)
+		}
+	}
+
+	return issue, nil
+}
+
+// issueMetadata resolves the repository, labels and milestone of the options,
+// a missing label or milestone is an error rather than an unlabeled issue.
+func (g *ProjectManager) issueMetadata(opts IssueOptions) (*issueMetadata, error) {
+	owner, name, err := opts.repository(g.repository)
+	if err != nil {
+		return nil, err
+	}
+
+	var query struct {
+		Repository struct {
+			ID         g4.ID
+			Milestones struct {
+				Nodes []struct {
+					ID    g4.ID
+					Title g4.String
+				}
+			} `graphql:"milestones(first: 20, states: OPEN, query: $milestone)"`
+		} `graphql:"repository(owner: $owner, name: $name)"`
+	}
+	variables := map[string]interface{}{
+		"owner":     g4.String(owner),
+		"name":      g4.String(name),
+		"milestone": g4.String(opts.Milestone),
+	}
+	if err := g.githubClient.Query(context.Background(), &query, variables); err != nil {
+		return nil, fmt.Errorf("failed to query repository %s/%s: %w", owner, name, err)
+	}
+
+	metadata := &issueMetadata{repositoryID: query.Repository.ID}
+	if opts.Milestone != "" {
+		for _, milestone := range query.Repository.Milestones.Nodes {
+			if string(milestone.Title) == opts.Milestone {
+				metadata.milestoneID = &milestone.ID
+				break
+			}
+		}
+		if metadata.milestoneID == nil {
+			return nil, fmt.Errorf("milestone %q not found in %s/%s", opts.Milestone, owner, name)
+		}
+	}
+
+	for _, label := range opts.Labels {
+		var labelQuery struct {
+			Repository struct {
+				Label *struct {
+					ID g4.ID
+				} `graphql:"label(name: $label)"`
+			} `graphql:"repository(owner: $owner, name: $name)"`
+		}
+		variables := map[string]interface{}{
+			"owner": g4.String(owner),
+			"name":  g4.String(name),
+			"label": g4.String(label),
+		}
+		if err := g.githubClient.Query(context.Background(), &labelQuery, variables); err != nil {
+			return nil, fmt.Errorf("failed to query label %q: %w", label, err)
+		}
+		if labelQuery.Repository.Label == nil {
+			return nil, fmt.Errorf("label %q not found in %s/%s", label, owner, name)
+		}
+		metadata.labelIDs = append(metadata.labelIDs, labelQuery.Repository.Label.ID)
+	}
+	return metadata, nil
+}
@@ -0,0 +1,36 @@
+package github
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIssueOptionsRepository(t *testing.T) {
+	tests := []struct {
+		name          string
+		repository    string
+		expectedOwner string
+		expectedName  string
+		expectedErr   bool
+	}{
+		{name: "default repository", expectedOwner: "example", expectedName: "kubernetes"},
+		{name: "owner and name", repository: "kubernetes/test-infra", expectedOwner: "kubernetes", expectedName: "test-infra"},
+		{name: "missing name", repository: "kubernetes", expectedErr: true},
+		{name: "empty owner", repository: "/kubernetes", expectedErr: true},
+		{name: "nested name", repository: "kubernetes/test-infra/prow", expectedErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			owner, name, err := IssueOptions{Repository: tt.repository}.repository("example/kubernetes")
+			if tt.expectedErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedOwner, owner)
+			assert.Equal(t, tt.expectedName, name)
+		})
+	}
+}
@@ -0,0 +1,188 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	g4 "github.com/shurcooL/githubv4"
+)
+
+// Special options of the project field mapping, resolved for each item.
+const (
+	// LatestReleaseOption picks the option with the highest version, e.g. v1.35.
+	LatestReleaseOption = "$latest"
+	// BoardOption picks the option named after the TestGrid board, e.g. master-blocking.
+	BoardOption = "$board"
+)
+
+// DefaultFields sets the release, view, board and status of the items of the CI signal project.
+var DefaultFields = map[string]string{
+	"K8s Release":    LatestReleaseOption,
+	"View":           "issue-tracking",
+	"Testgrid Board": BoardOption,
+	"Status":         "Drafting",
+}
+
+// ProjectConfig selects the GitHub project of the drafts and the options set on their fields.
+type ProjectConfig struct {
+	// Organization owns the project.
+	Organization string `json:"organization,omitempty"`
+	// Repository is the owner/name of the code repository, searched for the
+	// merged pull requests and the duplicate issues, and where issues are filed
+	// by default. It does not follow the organization of the project.
+	Repository string `json:"repository,omitempty"`
+	// ProjectNumber is the number of the project in the organization URL,
+	// the CI signal project of the kubernetes organization when unset.
+	ProjectNumber int `json:"projectNumber,omitempty"`
+	// Fields maps the single select field names to the option set on the new items,
+	// replacing DefaultFields when not empty.
+	Fields map[string]string `json:"fields,omitempty"`
+}
+
+// Override returns the config with the non-empty fields of other.
+func (c ProjectConfig) Override(other ProjectConfig) ProjectConfig {
+	if other.Organization != "" {
+		c.Organization = other.Organization
+	}
+	if other.ProjectNumber != 0 {
+		c.ProjectNumber = other.ProjectNumber
+	}
+	if other.Repository != "" {
+		c.Repository = other.Repository
+	}
+	if len(other.Fields) > 0 {
+		c.Fields = other.Fields
+	}
+	return c
+}
+
+// WithDefaults fills the organization, the repository and the field mapping of the CI signal project.
+func (c ProjectConfig) WithDefaults() ProjectConfig {
+	return ProjectConfig{Organization: DefaultOrganization, Repository: DefaultRepository, Fields: DefaultFields}.Override(c)
+}
+
+// Validate checks a project number is given for any other organization,
+// since the default project only exists in the kubernetes one, and the
+// repository is an owner/name pair.
+func (c ProjectConfig) Validate() error {
+	if c.ProjectNumber < 0 {
+		return fmt.Errorf("invalid project number %d", c.ProjectNumber)
+	}
+	if c.Organization != "" && c.Organization != DefaultOrganization && c.ProjectNumber == 0 {
+		return fmt.Errorf("a project number is required for the organization %s", c.Organization)
+	}
+	if c.Repository != "" {
+		if _, _, err := splitRepository(c.Repository); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// splitRepository returns the owner and name of an owner/name repository.
+func splitRepository(repository string) (owner, name string, err error) {
+	owner, name, found := strings.Cut(repository, "/")
+	if !found || owner == "" || name == "" || strings.Contains(name, "/") {
+		return "", "", fmt.Errorf("invalid repository %q, expected owner/name", repository)
+	}
+	return owner, name, nil
+}
+
+// resolveProject looks up the node ID of the project number in the
+// organization, once, and keeps the default project without number.
+func (g *ProjectManager) resolveProject() error {
+	if g.githubClient == nil {
+		return errors.New("github GraphQL client is nil")
+	}
+	if g.projectID != "" {
+		return nil
+	}
+	if g.projectNumber == 0 {
+		g.projectID = DefaultProjectID
+		return nil
+	}
+
+	var query struct {
+		Organization struct {
+			ProjectV2 struct {
+				ID g4.ID
+			} `graphql:"projectV2(number: $number)"`
+		} `graphql:"organization(login: $login)"`
+	}
+	variables := map[string]interface{}{
+		"login":  g4.String(g.organization),
+		"number": g4.Int(g.projectNumber),
+	}
+	if err := g.githubClient.Query(context.Background(), &query, variables); err != nil {
+		return fmt.Errorf("failed to query project %d of %s: %w", g.projectNumber, g.organization, err)
+	}
+	projectID, ok := query.Organization.ProjectV2.ID.(string)
+	if !ok || projectID == "" {
+		return fmt.Errorf("project %d not found in %s", g.projectNumber, g.organization)
+	}
+	g.projectID = projectID
+	return nil
+}
+
+// fieldOption returns the option mapped to the field name, ignoring case.
+func (g *ProjectManager) fieldOption(fieldName string) (string, bool) {
+	for name, option := range g.fieldOptions {
+		if strings.EqualFold(name, fieldName) {
+			return option, true
+		}
+	}
+	return "", false
+}
+
+// selectOption returns the ID of the option matching the desired one for the
+// board: an exact match ignoring case first, then the first option containing it.
+func selectOption(options map[string]interface{}, desired, board string) g4.ID {
+	names := make([]string, 0, len(options))
+	for name := range options {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	switch desired {
+	case LatestReleaseOption:
+		// find the latest version option (highest version number)
+		latestVersion, latestName := "", ""
+		for _, name := range names {
+			// extract version number from option name (e.g., "v1.32" -> "1.32")
+			if version := extractVersion(name); version != "" {
+				if latestVersion == "" || compareVersions(version, latestVersion) > 0 {
+					latestVersion, latestName = version, name
+				}
+			}
+		}
+		if latestName != "" {
+			return options[latestName]
+		}
+	case BoardOption:
+		// the longest option within the board, master-blocking over blocking
+		boardName := ""
+		for _, name := range names {
+			if strings.Contains(strings.ToLower(board), strings.ToLower(name)) && len(name) > len(boardName) {
+				boardName = name
+			}
+		}
+		if boardName != "" {
+			return options[boardName]
+		}
+	default:
+		for _, name := range names {
+			if strings.EqualFold(name, desired) {
+				return options[name]
+			}
+		}
+		for _, name := range names {
+			if strings.Contains(strings.ToLower(name), strings.ToLower(desired)) {
+				return options[name]
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,67 @@
+package github
+
+import (
+	"testing"
+
+	g4 "github.com/shurcooL/githubv4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSelectOption(t *testing.T) {
+	options := map[string]interface{}{
+		"v1.9":            "release-9",
+		"v1.35":           "release-35",
+		"v1.34":           "release-34",
+		"blocking":        "blocking",
+		"master-blocking": "master-blocking",
+		"Drafting":        "drafting",
+		"Under Review":    "under-review",
+	}
+
+	tests := []struct {
+		name     string
+		desired  string
+		board    string
+		expected g4.ID
+	}{
+		{name: "latest release by version", desired: LatestReleaseOption, expected: "release-35"},
+		{name: "longest option within the board", desired: BoardOption, board: "sig-release-master-blocking", expected: "master-blocking"},
+		{name: "board without option", desired: BoardOption, board: "sig-node-kubelet"},
+		{name: "exact match ignoring case", desired: "drafting", expected: "drafting"},
+		{name: "option containing the desired one", desired: "review", expected: "under-review"},
+		{name: "no matching option", desired: "Done"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, selectOption(options, tt.desired, tt.board))
+		})
+	}
+}
+
+func TestProjectConfigValidate(t *testing.T) {
+	tests := []struct {
+		name        string
+		config      ProjectConfig
+		expectedErr bool
+	}{
+		{name: "default project", config: ProjectConfig{}},
+		{name: "default organization", config: ProjectConfig{Organization: DefaultOrganization}},
+		{name: "project number of another organization", config: ProjectConfig{Organization: "example", ProjectNumber: 3}},
+		{name: "missing project number", config: ProjectConfig{Organization: "example"}, expectedErr: true},
+		{name: "negative project number", config: ProjectConfig{ProjectNumber: -1}, expectedErr: true},
+		{name: "repository of another organization", config: ProjectConfig{Repository: "kubernetes-sigs/signalhound"}},
+		{name: "repository without owner", config: ProjectConfig{Repository: "signalhound"}, expectedErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.config.Validate()
+			if tt.expectedErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
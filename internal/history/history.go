@@ -0,0 +1,222 @@
+package history
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"sigs.k8s.io/signalhound/api/v1alpha1"
+	"sigs.k8s.io/signalhound/internal/testgrid"
+)
+
+const (
+	// DefaultRetention is how long entries are kept before being pruned.
+	DefaultRetention = 30 * 24 * time.Hour
+
+	// dayLayout names the files, one per UTC day.
+	dayLayout = "2006-01-02"
+	fileExt   = ".jsonl"
+
+	// maxLineSize bounds a single entry, tabs carry the error messages.
+	maxLineSize = 64 * 1024 * 1024
+)
+
+// Entry is the state of the boards fetched at a given time.
+type Entry struct {
+	Time time.Time `json:"time"`
+	// Dashboards are the TestGrid dashboard names that were scraped, passing
+	// ones included, empty in the entries written before they were recorded.
+	Dashboards []string                 `json:"dashboards,omitempty"`
+	Tabs       []*v1alpha1.DashboardTab `json:"tabs"`
+}
+
+// TestRecord is the state of a test in a stored entry.
+type TestRecord struct {
+	Time      time.Time
+	BoardHash string
+	TabState  string
+	Result    v1alpha1.TestResult
+}
+
+// Store appends entries as JSON lines in one file per day under Dir, files
+// older than the retention are removed on append.
+type Store struct {
+	Dir       string
+	Retention time.Duration
+}
+
+// New returns a store writing under dir, a zero retention keeps everything.
+func New(dir string, retention time.Duration) *Store {
+	return &Store{Dir: dir, Retention: retention}
+}
+
+// Append writes the tabs of the dashboards fetched at the given time and prunes old entries.
+func (s *Store) Append(at time.Time, dashboards []string, tabs []*v1alpha1.DashboardTab) (err error) {
+	if err := os.MkdirAll(s.Dir, 0o755); err != nil {
+		return fmt.Errorf("error creating history directory: %w", err)
+	}
+	at = at.UTC()
+	data, err := json.Marshal(&Entry{Time: at, Dashboards: dashboards, Tabs: tabs})
+	if err != nil {
+		return fmt.Errorf("error encoding history entry: %w", err)
+	}
+
+	file, err := os.OpenFile(s.path(at), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return fmt.Errorf("error opening history file: %w", err)
+	}
+	defer func() {
+		if closeErr := file.Close(); err == nil {
+			err = closeErr
+		}
+	}()
+	if _, err = file.Write(append(data, '\n')); err != nil {
+		return fmt.Errorf("error writing history entry: %w", err)
+	}
+
+	if s.Retention > 0 {
+		return s.Prune(at.Add(-s.Retention))
+	}
+	return nil
+}
+
+// Prune removes the days ending before the given time.
+func (s *Store) Prune(before time.Time) error {
+	days, err := s.days()
+	if err != nil {
+		return err
+	}
+	for _, day := range days {
+		if day.Add(24 * time.Hour).After(before) {
+			continue
+		}
+		if err := os.Remove(s.path(day)); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("error pruning history: %w", err)
+		}
+	}
+	return nil
+}
+
+// Entries returns the entries stored between since and until included, oldest
+// first. A zero until means up to now.
+func (s *Store) Entries(since, until time.Time) ([]Entry, error) {
+	if until.IsZero() {
+		until = time.Now()
+	}
+	days, err := s.days()
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []Entry
+	for _, day := range days {
+		if day.Add(24*time.Hour).Before(since) || day.After(until) {
+			continue
+		}
+		dayEntries, err := s.readDay(day)
+		if err != nil {
+			return nil, err
+		}
+		for _, entry := range dayEntries {
+			if !entry.Time.Before(since) && !entry.Time.After(until) {
+				entries = append(entries, entry)
+			}
+		}
+	}
+	sort.SliceStable(entries, func(i, j int) bool { return entries[i].Time.Before(entries[j].Time) })
+	return entries, nil
+}
+
+// At returns the most recent entry stored at or before the given time.
+func (s *Store) At(at time.Time) (*Entry, error) {
+	entries, err := s.Entries(time.Time{}, at)
+	if err != nil {
+		return nil, err
+	}
+	if len(entries) == 0 {
+		return nil, fmt.Errorf("no history entry before %s", at.Format(time.RFC3339))
+	}
+	return &entries[len(entries)-1], nil
+}
+
+// TestHistory returns the records of a test since the given time, oldest
+// first. Names are compared once normalized, an empty boardHash matches all
+// the tabs.
+func (s *Store) TestHistory(boardHash, testName string, since time.Time) ([]TestRecord, error) {
+	entries, err := s.Entries(since, time.Time{})
+	if err != nil {
+		return nil, err
+	}
+
+	name := testgrid.NormalizeTestName(testName)
+	var records []TestRecord
+	for _, entry := range entries {
+		for _, tab := range entry.Tabs {
+			if boardHash != "" && tab.BoardHash != boardHash {
+				continue
+			}
+			for _, test := range tab.TestRuns {
+				if testgrid.NormalizeTestName(test.TestName) == name {
+					records = append(records, TestRecord{Time: entry.Time, BoardHash: tab.BoardHash, TabState: tab.TabState, Result: test})
+				}
+			}
+		}
+	}
+	return records, nil
+}
+
+// days returns the days having a history file, oldest first.
+func (s *Store) days() ([]time.Time, error) {
+	files, err := os.ReadDir(s.Dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading history directory: %w", err)
+	}
+
+	var days []time.Time
+	for _, file := range files {
+		day, err := time.Parse(dayLayout, strings.TrimSuffix(file.Name(), fileExt))
+		if err != nil || !strings.HasSuffix(file.Name(), fileExt) {
+			continue
+		}
+		days = append(days, day)
+	}
+	sort.Slice(days, func(i, j int) bool { return days[i].Before(days[j]) })
+	return days, nil
+}
+
+func (s *Store) readDay(day time.Time) ([]Entry, error) {
+	file, err := os.Open(s.path(day))
+	if err != nil {
+		return nil, fmt.Errorf("error opening history file: %w", err)
+	}
+	defer file.Close() // nolint: errcheck
+
+	var entries []Entry
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(nil, maxLineSize)
+	for scanner.Scan() {
+		var entry Entry
+		// skip a line truncated by an interrupted write
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			continue
+		}
+		entries = append(entries, entry)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("error reading history file %s: %w", file.Name(), err)
+	}
+	return entries, nil
+}
+
+func (s *Store) path(at time.Time) string {
+	return filepath.Join(s.Dir, at.UTC().Format(dayLayout)+fileExt)
+}
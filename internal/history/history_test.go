@@ -0,0 +1,84 @@
+package history
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"sigs.k8s.io/signalhound/api/v1alpha1"
+)
+
+func tabs(state string, tests ...string) []*v1alpha1.DashboardTab {
+	tab := &v1alpha1.DashboardTab{BoardHash: "sig-release-master-blocking#gce", TabState: state}
+	for _, test := range tests {
+		tab.TestRuns = append(tab.TestRuns, v1alpha1.TestResult{TestName: test})
+	}
+	return []*v1alpha1.DashboardTab{tab}
+}
+
+func TestStoreAppendAndQuery(t *testing.T) {
+	store := New(t.TempDir(), 0)
+	day := time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC)
+
+	assert.NoError(t, store.Append(day, []string{"sig-release-master-blocking"}, tabs(v1alpha1.FLAKY_STATUS, "Kubernetes e2e suite.[It] [sig-node] Pods")))
+	assert.NoError(t, store.Append(day.Add(time.Hour), nil, tabs(v1alpha1.FAILING_STATUS, "[sig-node] Pods", "other")))
+	assert.NoError(t, store.Append(day.Add(48*time.Hour), nil, tabs(v1alpha1.FAILING_STATUS, "other")))
+
+	entries, err := store.Entries(time.Time{}, time.Time{})
+	assert.NoError(t, err)
+	assert.Len(t, entries, 3)
+	assert.Equal(t, []string{"sig-release-master-blocking"}, entries[0].Dashboards)
+
+	records, err := store.TestHistory("", "[sig-node] Pods", time.Time{})
+	assert.NoError(t, err)
+	assert.Len(t, records, 2)
+	assert.Equal(t, v1alpha1.FLAKY_STATUS, records[0].TabState)
+	assert.Equal(t, v1alpha1.FAILING_STATUS, records[1].TabState)
+	assert.Equal(t, day.Add(time.Hour), records[1].Time)
+
+	records, err = store.TestHistory("sig-release-master-informing#gce", "[sig-node] Pods", time.Time{})
+	assert.NoError(t, err)
+	assert.Empty(t, records)
+
+	entry, err := store.At(day.Add(24 * time.Hour))
+	assert.NoError(t, err)
+	assert.Equal(t, day.Add(time.Hour), entry.Time)
+
+	_, err = store.At(day.Add(-time.Hour))
+	assert.Error(t, err)
+}
+
+func TestStoreRetention(t *testing.T) {
+	dir := t.TempDir()
+	store := New(dir, 7*24*time.Hour)
+	day := time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC)
+
+	assert.NoError(t, store.Append(day, nil, tabs(v1alpha1.FAILING_STATUS, "old")))
+	assert.NoError(t, store.Append(day.Add(10*24*time.Hour), nil, tabs(v1alpha1.FAILING_STATUS, "new")))
+
+	_, err := os.Stat(filepath.Join(dir, "2025-03-01.jsonl"))
+	assert.ErrorIs(t, err, os.ErrNotExist)
+
+	entries, err := store.Entries(time.Time{}, time.Time{})
+	assert.NoError(t, err)
+	assert.Len(t, entries, 1)
+}
+
+func TestStoreSkipsTruncatedLines(t *testing.T) {
+	dir := t.TempDir()
+	store := New(dir, 0)
+	day := time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC)
+	assert.NoError(t, store.Append(day, nil, tabs(v1alpha1.FAILING_STATUS, "test")))
+
+	file, err := os.OpenFile(filepath.Join(dir, "2025-03-01.jsonl"), os.O_APPEND|os.O_WRONLY, 0o644)
+	assert.NoError(t, err)
+	_, err = file.WriteString(`{"time":"2025-03-01T11:00`)
+	assert.NoError(t, err)
+	assert.NoError(t, file.Close())
+
+	entries, err := store.Entries(time.Time{}, time.Time{})
+	assert.NoError(t, err)
+	assert.Len(t, entries, 1)
+}
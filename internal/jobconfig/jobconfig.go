@@ -0,0 +1,215 @@
+package jobconfig
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"sigs.k8s.io/yaml"
+)
+
+// Job types indexed from the Prow config.
+const (
+	Periodic   = "periodic"
+	Postsubmit = "postsubmit"
+)
+
+// ownersFile lists the approvers of the jobs configured in its directory tree.
+const ownersFile = "OWNERS"
+
+// TestGrid annotations of the Prow jobs.
+const (
+	dashboardsAnnotation  = "testgrid-dashboards"
+	tabNameAnnotation     = "testgrid-tab-name"
+	alertEmailAnnotation  = "testgrid-alert-email"
+	descriptionAnnotation = "description"
+)
+
+// Job is the metadata of a Prow job useful when filing an issue.
+type Job struct {
+	Name        string
+	Type        string
+	Description string
+	Cluster     string
+	// Interval and Cron are the schedule of periodics.
+	Interval string
+	Cron     string
+	Timeout  string
+	// Owners are the approvers of the closest OWNERS file of the job config.
+	Owners      []string
+	AlertEmails []string
+	Dashboards  []string
+	TabName     string
+	// File is the job config file, relative to the indexed directory.
+	File string
+}
+
+// Index maps the TestGrid tabs to the Prow jobs of a test-infra config checkout.
+type Index struct {
+	byTab  map[string]*Job
+	byName map[string]*Job
+	// Skipped lists the YAML files that could not be parsed.
+	Skipped []string
+}
+
+type jobBase struct {
+	Name             string            `json:"name"`
+	Interval         string            `json:"interval,omitempty"`
+	Cron             string            `json:"cron,omitempty"`
+	Cluster          string            `json:"cluster,omitempty"`
+	Annotations      map[string]string `json:"annotations,omitempty"`
+	DecorationConfig *struct {
+		Timeout string `json:"timeout,omitempty"`
+	} `json:"decoration_config,omitempty"`
+}
+
+type jobFile struct {
+	Periodics   []jobBase            `json:"periodics,omitempty"`
+	Postsubmits map[string][]jobBase `json:"postsubmits,omitempty"`
+}
+
+type owners struct {
+	Approvers []string `json:"approvers,omitempty"`
+}
+
+// Load indexes the periodics and postsubmits of the YAML files under dir,
+// e.g. a test-infra checkout's config/jobs.
+func Load(dir string) (*Index, error) {
+	dir = filepath.Clean(dir)
+	index := &Index{byTab: map[string]*Job{}, byName: map[string]*Job{}}
+	ownersCache := map[string][]string{}
+
+	err := filepath.WalkDir(dir, func(file string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() || (filepath.Ext(file) != ".yaml" && filepath.Ext(file) != ".yml") {
+			return nil
+		}
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return err
+		}
+		var config jobFile
+		if err := yaml.Unmarshal(data, &config); err != nil {
+			index.Skipped = append(index.Skipped, file)
+			return nil
+		}
+		relative, _ := filepath.Rel(dir, file)
+		jobOwners := findOwners(dir, filepath.Dir(file), ownersCache)
+		for _, base := range config.Periodics {
+			index.add(newJob(base, Periodic, relative, jobOwners))
+		}
+		repos := make([]string, 0, len(config.Postsubmits))
+		for repo := range config.Postsubmits {
+			repos = append(repos, repo)
+		}
+		sort.Strings(repos)
+		for _, repo := range repos {
+			for _, base := range config.Postsubmits[repo] {
+				index.add(newJob(base, Postsubmit, relative, jobOwners))
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return index, nil
+}
+
+// Len returns the number of indexed jobs.
+func (i *Index) Len() int {
+	if i == nil {
+		return 0
+	}
+	return len(i.byName)
+}
+
+// Lookup returns the job of the Board#Tab, falling back to the job name
+// taken from the Prow job URL when the tab is not annotated.
+func (i *Index) Lookup(boardHash, prowJobURL string) *Job {
+	if i == nil {
+		return nil
+	}
+	if job, ok := i.byTab[boardHash]; ok {
+		return job
+	}
+	if prowJobURL != "" {
+		// the URL ends with the job name and the build ID
+		if job, ok := i.byName[path.Base(path.Dir(strings.TrimSuffix(prowJobURL, "/")))]; ok {
+			return job
+		}
+	}
+	if _, tab, found := strings.Cut(boardHash, "#"); found {
+		return i.byName[tab]
+	}
+	return nil
+}
+
+func (i *Index) add(job *Job) {
+	if job.Name == "" {
+		return
+	}
+	i.byName[job.Name] = job
+	for _, dashboard := range job.Dashboards {
+		i.byTab[dashboard+"#"+job.TabName] = job
+	}
+}
+
+func newJob(base jobBase, jobType, file string, jobOwners []string) *Job {
+	job := &Job{
+		Name:        base.Name,
+		Type:        jobType,
+		Description: base.Annotations[descriptionAnnotation],
+		Cluster:     base.Cluster,
+		Interval:    base.Interval,
+		Cron:        base.Cron,
+		Owners:      jobOwners,
+		AlertEmails: splitList(base.Annotations[alertEmailAnnotation]),
+		Dashboards:  splitList(base.Annotations[dashboardsAnnotation]),
+		TabName:     base.Annotations[tabNameAnnotation],
+		File:        file,
+	}
+	if base.DecorationConfig != nil {
+		job.Timeout = base.DecorationConfig.Timeout
+	}
+	if job.TabName == "" {
+		job.TabName = job.Name
+	}
+	return job
+}
+
+// findOwners returns the approvers of the closest OWNERS file from dir up to root.
+func findOwners(root, dir string, cache map[string][]string) []string {
+	if approvers, ok := cache[dir]; ok {
+		return approvers
+	}
+	var approvers []string
+	data, err := os.ReadFile(filepath.Join(dir, ownersFile))
+	switch {
+	case err == nil:
+		var file owners
+		if yaml.Unmarshal(data, &file) == nil {
+			approvers = file.Approvers
+		}
+	case errors.Is(err, fs.ErrNotExist) && dir != root && filepath.Dir(dir) != dir:
+		approvers = findOwners(root, filepath.Dir(dir), cache)
+	}
+	cache[dir] = approvers
+	return approvers
+}
+
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
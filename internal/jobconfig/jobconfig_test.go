@@ -0,0 +1,99 @@
+package jobconfig
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func writeFile(t *testing.T, path, content string) {
+	assert.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+	assert.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+}
+
+func TestLoad(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "OWNERS"), "approvers:\n- release-team\n")
+	writeFile(t, filepath.Join(dir, "kubernetes/sig-node", "OWNERS"), "approvers:\n- node-lead\n- node-approver\n")
+	writeFile(t, filepath.Join(dir, "kubernetes/sig-node", "node.yaml"), `periodics:
+- name: ci-kubernetes-node-e2e-containerd
+  interval: 1h
+  cluster: k8s-infra-prow-build
+  decoration_config:
+    timeout: 90m
+  annotations:
+    testgrid-dashboards: sig-release-master-blocking, sig-node-release-blocking
+    testgrid-tab-name: node-kubelet-containerd
+    testgrid-alert-email: sig-node-alerts@example.com, release-alerts@example.com
+    description: Runs the node e2e tests with containerd
+`)
+	writeFile(t, filepath.Join(dir, "kubernetes/sig-release", "build.yaml"), `postsubmits:
+  kubernetes/kubernetes:
+  - name: ci-kubernetes-build
+    cluster: k8s-infra-prow-build
+periodics:
+- name: ci-kubernetes-e2e-gce
+  cron: "0 */3 * * *"
+`)
+	writeFile(t, filepath.Join(dir, "broken.yaml"), "periodics: [\n")
+	writeFile(t, filepath.Join(dir, "README.md"), "not a job")
+
+	index, err := Load(dir)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, index.Len())
+	assert.Equal(t, []string{filepath.Join(dir, "broken.yaml")}, index.Skipped)
+
+	node := &Job{
+		Name:        "ci-kubernetes-node-e2e-containerd",
+		Type:        Periodic,
+		Description: "Runs the node e2e tests with containerd",
+		Cluster:     "k8s-infra-prow-build",
+		Interval:    "1h",
+		Timeout:     "90m",
+		Owners:      []string{"node-lead", "node-approver"},
+		AlertEmails: []string{"sig-node-alerts@example.com", "release-alerts@example.com"},
+		Dashboards:  []string{"sig-release-master-blocking", "sig-node-release-blocking"},
+		TabName:     "node-kubelet-containerd",
+		File:        filepath.Join("kubernetes", "sig-node", "node.yaml"),
+	}
+	assert.Equal(t, node, index.Lookup("sig-node-release-blocking#node-kubelet-containerd", ""))
+
+	tests := []struct {
+		name       string
+		boardHash  string
+		prowJobURL string
+		expected   string
+	}{
+		{name: "tab named after the job", boardHash: "sig-release-master-informing#ci-kubernetes-e2e-gce", expected: "ci-kubernetes-e2e-gce"},
+		{
+			name:       "job from the Prow URL",
+			boardHash:  "sig-release-master-informing#gce",
+			prowJobURL: "https://prow.k8s.io/view/gs/kubernetes-ci-logs/logs/ci-kubernetes-e2e-gce/1900",
+			expected:   "ci-kubernetes-e2e-gce",
+		},
+		{name: "postsubmit", boardHash: "sig-release-master-blocking#ci-kubernetes-build", expected: "ci-kubernetes-build"},
+		{name: "unknown tab", boardHash: "sig-release-master-blocking#unknown"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			job := index.Lookup(tt.boardHash, tt.prowJobURL)
+			if tt.expected == "" {
+				assert.Nil(t, job)
+				return
+			}
+			assert.Equal(t, tt.expected, job.Name)
+			assert.Equal(t, []string{"release-team"}, job.Owners)
+		})
+	}
+
+	var empty *Index
+	assert.Nil(t, empty.Lookup("sig-release-master-blocking#gce", ""))
+	assert.Zero(t, empty.Len())
+}
+
+func TestLoadMissingDir(t *testing.T) {
+	_, err := Load(filepath.Join(t.TempDir(), "missing"))
+	assert.Error(t, err)
+}
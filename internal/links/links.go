@@ -0,0 +1,97 @@
+package links
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// Defaults point to the upstream Kubernetes infrastructure.
+const (
+	DefaultTestGridURL = "https://testgrid.k8s.io"
+	DefaultProwURL     = "https://prow.k8s.io"
+	DefaultArtifactURL = "https://storage.googleapis.com"
+	DefaultTriageURL   = "https://storage.googleapis.com/k8s-triage"
+	DefaultStorage     = "gs"
+)
+
+// Config holds the base URLs every TestGrid, Prow, artifact and triage link
+// is built from, so signalhound can follow a downstream CI.
+type Config struct {
+	// TestGridURL is the TestGrid instance, used for its API and the tab links.
+	TestGridURL string `json:"testgridURL,omitempty"`
+	// ProwURL is the Prow deck instance serving the job pages.
+	ProwURL string `json:"prowURL,omitempty"`
+	// ArtifactURL is the public endpoint of the bucket storing the job artifacts.
+	ArtifactURL string `json:"artifactURL,omitempty"`
+	// TriageURL is the triage dashboard, its index.html filters by job and test.
+	TriageURL string `json:"triageURL,omitempty"`
+	// Storage is the storage provider of the Prow job view path, e.g. gs or s3.
+	Storage string `json:"storage,omitempty"`
+}
+
+// Default returns the upstream Kubernetes links.
+func Default() Config {
+	return Config{
+		TestGridURL: DefaultTestGridURL,
+		ProwURL:     DefaultProwURL,
+		ArtifactURL: DefaultArtifactURL,
+		TriageURL:   DefaultTriageURL,
+		Storage:     DefaultStorage,
+	}
+}
+
+// WithDefaults fills the unset fields with the upstream Kubernetes links
+// and drops the trailing slashes.
+func (c Config) WithDefaults() Config {
+	return Default().Override(c)
+}
+
+// Override returns the config with the non-empty fields of other.
+func (c Config) Override(other Config) Config {
+	return Config{
+		TestGridURL: pick(other.TestGridURL, c.TestGridURL),
+		ProwURL:     pick(other.ProwURL, c.ProwURL),
+		ArtifactURL: pick(other.ArtifactURL, c.ArtifactURL),
+		TriageURL:   pick(other.TriageURL, c.TriageURL),
+		Storage:     pick(other.Storage, c.Storage),
+	}
+}
+
+func pick(value, fallback string) string {
+	if value == "" {
+		value = fallback
+	}
+	return strings.TrimRight(value, "/")
+}
+
+// Validate checks the base URLs are absolute.
+func (c Config) Validate() error {
+	names := []string{"testgrid", "prow", "artifact", "triage"}
+	for i, value := range []string{c.TestGridURL, c.ProwURL, c.ArtifactURL, c.TriageURL} {
+		if value == "" {
+			continue
+		}
+		parsed, err := url.Parse(value)
+		if err != nil || parsed.Scheme == "" || parsed.Host == "" {
+			return fmt.Errorf("invalid %s URL %q, an absolute URL is expected", names[i], value)
+		}
+	}
+	return nil
+}
+
+// Tab returns the TestGrid link of a Board#Tab showing the failed tests only.
+func (c Config) Tab(boardHash string) string {
+	return fmt.Sprintf("%s/%s&exclude-non-failed-tests=", c.TestGridURL, boardHash)
+}
+
+// ProwJob returns the Prow page of a build, from the TestGroup query, e.g.
+// kubernetes-ci-logs/logs/ci-kubernetes-e2e-gce, and the build ID.
+func (c Config) ProwJob(query, build string) string {
+	return fmt.Sprintf("%s/view/%s/%s/%s", c.ProwURL, c.Storage, query, build)
+}
+
+// Triage returns the triage dashboard filtered on the job and test.
+func (c Config) Triage(job, test string) string {
+	return fmt.Sprintf("%s/index.html?job=%s$&test=%s", c.TriageURL, job, test)
+}
@@ -0,0 +1,38 @@
+package links
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfig(t *testing.T) {
+	upstream := Default()
+	assert.Equal(t, "https://testgrid.k8s.io/sig-release-master-blocking#gce&exclude-non-failed-tests=",
+		upstream.Tab("sig-release-master-blocking#gce"))
+	assert.Equal(t, "https://prow.k8s.io/view/gs/kubernetes-ci-logs/logs/ci-kubernetes-e2e-gce/1900",
+		upstream.ProwJob("kubernetes-ci-logs/logs/ci-kubernetes-e2e-gce", "1900"))
+	assert.Equal(t, "https://storage.googleapis.com/k8s-triage/index.html?job=ci-kubernetes-e2e-gce$&test=Pods",
+		upstream.Triage("ci-kubernetes-e2e-gce", "Pods"))
+
+	downstream := Config{ProwURL: "https://prow.example.com/", Storage: "s3"}.WithDefaults()
+	assert.Equal(t, Config{
+		TestGridURL: DefaultTestGridURL,
+		ProwURL:     "https://prow.example.com",
+		ArtifactURL: DefaultArtifactURL,
+		TriageURL:   DefaultTriageURL,
+		Storage:     "s3",
+	}, downstream)
+	assert.Equal(t, "https://prow.example.com/view/s3/bucket/logs/job/1", downstream.ProwJob("bucket/logs/job", "1"))
+
+	overridden := downstream.Override(Config{TestGridURL: "https://testgrid.example.com"})
+	assert.Equal(t, "https://testgrid.example.com", overridden.TestGridURL)
+	assert.Equal(t, "https://prow.example.com", overridden.ProwURL)
+}
+
+func TestValidate(t *testing.T) {
+	assert.NoError(t, Default().Validate())
+	assert.NoError(t, Config{}.Validate())
+	assert.EqualError(t, Config{ProwURL: "prow.example.com"}.Validate(),
+		`invalid prow URL "prow.example.com", an absolute URL is expected`)
+}
@@ -0,0 +1,208 @@
+package logscan
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Kind is the failure pattern an excerpt was recognised by.
+type Kind string
+
+const (
+	Panic     Kind = "panic"
+	Ginkgo    Kind = "ginkgo"
+	GoTest    Kind = "go-test"
+	Timeout   Kind = "timeout"
+	Kubetest2 Kind = "kubetest2"
+)
+
+// relevance ranks the kinds, a panic explains more than a timeout.
+var relevance = map[Kind]int{Panic: 100, Ginkgo: 90, GoTest: 80, Timeout: 60, Kubetest2: 50}
+
+const (
+	// MaxExcerpts is the number of excerpts returned by Scan.
+	MaxExcerpts = 10
+	// maxBlockLines caps the length of a single excerpt.
+	maxBlockLines = 60
+	// contextLines are kept before single line matches.
+	contextLines = 2
+)
+
+var (
+	panicRegex      = regexp.MustCompile(`(^|\s)panic: `)
+	goroutineRegex  = regexp.MustCompile(`^goroutine \d+ \[`)
+	ginkgoRegex     = regexp.MustCompile(`\[FAILED\]|\[FAIL\]|• Failure|\[PANICKED\]|\[TIMEDOUT\]`)
+	goTestFailRegex = regexp.MustCompile(`^\s*--- FAIL: (\S+)`)
+	goTestRunRegex  = regexp.MustCompile(`^=== RUN\s+(\S+)`)
+	timeoutRegex    = regexp.MustCompile(`(?i)timed out waiting`)
+	kubetest2Regex  = regexp.MustCompile(`(?i)kubetest2.*(fail|error)|^Error: |exit status \d+$`)
+	separatorRegex  = regexp.MustCompile(`^\s*-{10,}\s*$`)
+)
+
+// Excerpt is a block of the build log explaining a failure.
+type Excerpt struct {
+	Kind Kind
+	// StartLine and EndLine are the 1-indexed inclusive line range of the block.
+	StartLine int
+	EndLine   int
+	Text      string
+}
+
+// String prints the excerpt under a header with its line range.
+func (e Excerpt) String() string {
+	return fmt.Sprintf("# %s, lines %d-%d\n%s", e.Kind, e.StartLine, e.EndLine, e.Text)
+}
+
+// Scan returns the failure blocks of a raw build log, the most relevant
+// first. Blocks nested in a more relevant one are dropped, and the ones
+// overlapping it are clipped to the lines it does not show.
+func Scan(log string) []Excerpt {
+	lines := strings.Split(strings.ReplaceAll(log, "\r\n", "\n"), "\n")
+
+	var candidates []Excerpt
+	for i := 0; i < len(lines); i++ {
+		line := lines[i]
+		switch {
+		case panicRegex.MatchString(line):
+			candidates = append(candidates, block(lines, Panic, i, panicEnd(lines, i)))
+		case ginkgoRegex.MatchString(line):
+			candidates = append(candidates, block(lines, Ginkgo, i, paragraphEnd(lines, i)))
+		case goTestFailRegex.MatchString(line):
+			candidates = append(candidates, block(lines, GoTest, goTestStart(lines, i), indentedEnd(lines, i)))
+		case timeoutRegex.MatchString(line):
+			candidates = append(candidates, block(lines, Timeout, max(i-contextLines, 0), i))
+		case kubetest2Regex.MatchString(line):
+			candidates = append(candidates, block(lines, Kubetest2, max(i-contextLines, 0), i))
+		}
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return relevance[candidates[i].Kind] > relevance[candidates[j].Kind]
+	})
+	var excerpts []Excerpt
+	for _, candidate := range candidates {
+		if clipped, ok := clip(lines, candidate, excerpts); ok {
+			excerpts = append(excerpts, clipped)
+		}
+		if len(excerpts) == MaxExcerpts {
+			break
+		}
+	}
+	return excerpts
+}
+
+// Text prints the excerpts one after the other.
+func Text(excerpts []Excerpt) string {
+	blocks := make([]string, 0, len(excerpts))
+	for _, excerpt := range excerpts {
+		blocks = append(blocks, excerpt.String())
+	}
+	return strings.Join(blocks, "\n\n")
+}
+
+// block builds the excerpt of the 0-indexed lines from start to end.
+func block(lines []string, kind Kind, start, end int) Excerpt {
+	end = min(end, start+maxBlockLines-1, len(lines)-1)
+	return Excerpt{
+		Kind:      kind,
+		StartLine: start + 1,
+		EndLine:   end + 1,
+		Text:      strings.Join(lines[start:end+1], "\n"),
+	}
+}
+
+// panicEnd follows the goroutine traces printed after a panic.
+func panicEnd(lines []string, start int) int {
+	end, inTrace := start, false
+	for i := start + 1; i < len(lines); i++ {
+		line := lines[i]
+		switch {
+		case goroutineRegex.MatchString(line):
+			inTrace = true
+		case strings.TrimSpace(line) == "":
+			if inTrace {
+				// a blank line ends a trace, another goroutine may follow
+				if i+1 < len(lines) && goroutineRegex.MatchString(lines[i+1]) {
+					continue
+				}
+				return end
+			}
+		case !inTrace && i > start+contextLines:
+			return end
+		}
+		end = i
+	}
+	return end
+}
+
+// paragraphEnd stops at the first blank line or separator.
+func paragraphEnd(lines []string, start int) int {
+	end := start
+	for i := start + 1; i < len(lines); i++ {
+		if strings.TrimSpace(lines[i]) == "" || separatorRegex.MatchString(lines[i]) {
+			break
+		}
+		end = i
+	}
+	return end
+}
+
+// goTestStart goes back to the === RUN line of the failed test.
+func goTestStart(lines []string, failure int) int {
+	name := goTestFailRegex.FindStringSubmatch(lines[failure])[1]
+	for i := failure - 1; i >= 0 && i > failure-maxBlockLines; i-- {
+		if match := goTestRunRegex.FindStringSubmatch(lines[i]); match != nil && match[1] == name {
+			return i
+		}
+	}
+	return failure
+}
+
+// indentedEnd includes the indented output following a --- FAIL line.
+func indentedEnd(lines []string, start int) int {
+	indent := len(lines[start]) - len(strings.TrimLeft(lines[start], " \t"))
+	end := start
+	for i := start + 1; i < len(lines); i++ {
+		line := lines[i]
+		if strings.TrimSpace(line) == "" || len(line)-len(strings.TrimLeft(line, " \t")) <= indent {
+			break
+		}
+		end = i
+	}
+	return end
+}
+
+// clip shrinks the excerpt to the lines not covered by the selected excerpts,
+// keeping the lines before a selected excerpt within it, and trims the blank
+// lines left at its edges. It returns false when no line is left.
+func clip(lines []string, excerpt Excerpt, selected []Excerpt) (Excerpt, bool) {
+	start, end := excerpt.StartLine, excerpt.EndLine
+	for _, other := range selected {
+		if other.EndLine < start || other.StartLine > end {
+			continue
+		}
+		if other.StartLine <= start {
+			start = other.EndLine + 1
+		} else {
+			end = other.StartLine - 1
+		}
+		if start > end {
+			return Excerpt{}, false
+		}
+	}
+	for start <= end && strings.TrimSpace(lines[start-1]) == "" {
+		start++
+	}
+	for end >= start && strings.TrimSpace(lines[end-1]) == "" {
+		end--
+	}
+	if start > end {
+		return Excerpt{}, false
+	}
+	if start == excerpt.StartLine && end == excerpt.EndLine {
+		return excerpt, true
+	}
+	return block(lines, excerpt.Kind, start-1, end-1), true
+}
@@ -0,0 +1,96 @@
+package logscan
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScan(t *testing.T) {
+	tests := []struct {
+		name     string
+		log      []string
+		expected []Excerpt
+	}{
+		{
+			name: "ginkgo failure with a nested timeout",
+			log: []string{
+				"I0102 10:00:00.000 STEP: creating the pod",
+				"  [FAILED] timed out waiting for the condition",
+				"  In [It] at: test/e2e/node/pods.go:123 @ 01/02/25 10:00:00.000",
+				"------------------------------",
+				"done",
+			},
+			expected: []Excerpt{{Kind: Ginkgo, StartLine: 2, EndLine: 3, Text: "  [FAILED] timed out waiting for the condition\n" +
+				"  In [It] at: test/e2e/node/pods.go:123 @ 01/02/25 10:00:00.000"}},
+		},
+		{
+			name: "go test failure",
+			log: []string{
+				"=== RUN   TestOther",
+				"--- PASS: TestOther (0.00s)",
+				"=== RUN   TestSyncPod",
+				"    kubelet_test.go:42: pod was not synced",
+				"--- FAIL: TestSyncPod (0.50s)",
+				"FAIL",
+			},
+			expected: []Excerpt{
+				{Kind: GoTest, StartLine: 3, EndLine: 5, Text: "=== RUN   TestSyncPod\n    kubelet_test.go:42: pod was not synced\n--- FAIL: TestSyncPod (0.50s)"},
+			},
+		},
+		{
+			name: "panic ranks first with its goroutine trace",
+			log: []string{
+				"E0102 10:00:00.000 error: timed out waiting for the cluster",
+				"panic: runtime error: invalid memory address or nil pointer dereference",
+				"",
+				"goroutine 1 [running]:",
+				"main.main()",
+				"\t/go/src/main.go:10 +0x1d",
+				"",
+				"goroutine 2 [chan receive]:",
+				"main.worker()",
+				"",
+				"Error: exit status 2",
+			},
+			expected: []Excerpt{
+				{Kind: Panic, StartLine: 2, EndLine: 9, Text: "panic: runtime error: invalid memory address or nil pointer dereference\n\n" +
+					"goroutine 1 [running]:\nmain.main()\n\t/go/src/main.go:10 +0x1d\n\ngoroutine 2 [chan receive]:\nmain.worker()"},
+				{Kind: Timeout, StartLine: 1, EndLine: 1, Text: "E0102 10:00:00.000 error: timed out waiting for the cluster"},
+				{Kind: Kubetest2, StartLine: 11, EndLine: 11, Text: "Error: exit status 2"},
+			},
+		},
+		{
+			name: "overlapping blocks are clipped",
+			log: []string{
+				"I0102 10:00:00.000 waiting for the nodes",
+				"E0102 10:00:00.000 timed out waiting for the nodes",
+				"  [FAILED] the cluster is not ready",
+				"  In [BeforeSuite] at: test/e2e/e2e.go:77",
+			},
+			expected: []Excerpt{
+				{Kind: Ginkgo, StartLine: 3, EndLine: 4, Text: "  [FAILED] the cluster is not ready\n  In [BeforeSuite] at: test/e2e/e2e.go:77"},
+				{Kind: Timeout, StartLine: 1, EndLine: 2, Text: "I0102 10:00:00.000 waiting for the nodes\nE0102 10:00:00.000 timed out waiting for the nodes"},
+			},
+		},
+		{
+			name: "nothing to report",
+			log:  []string{"all good", "PASS"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, Scan(strings.Join(tt.log, "\n")))
+		})
+	}
+}
+
+func TestText(t *testing.T) {
+	excerpts := []Excerpt{
+		{Kind: Panic, StartLine: 2, EndLine: 3, Text: "panic: boom\nmain.main()"},
+		{Kind: Timeout, StartLine: 1, EndLine: 1, Text: "timed out waiting"},
+	}
+	assert.Equal(t, "# panic, lines 2-3\npanic: boom\nmain.main()\n\n# timeout, lines 1-1\ntimed out waiting", Text(excerpts))
+	assert.Empty(t, Text(nil))
+}
@@ -0,0 +1,226 @@
+package prow
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"regexp"
+	"strings"
+
+	"sigs.k8s.io/signalhound/internal/links"
+)
+
+// GCSURL is the public endpoint of the bucket holding the Prow job results.
+var GCSURL = links.DefaultArtifactURL
+
+// viewRegex matches the path of the Prow job pages up to the storage provider, e.g. /view/gs/.
+var viewRegex = regexp.MustCompile(`/view/[\w-]+/`)
+
+// ErrObjectNotFound is returned when the object does not exist in the bucket.
+var ErrObjectNotFound = errors.New("object not found")
+
+// Started is the content of the started.json file of a build.
+type Started struct {
+	Timestamp   int64             `json:"timestamp"`
+	Node        string            `json:"node,omitempty"`
+	Repos       map[string]string `json:"repos,omitempty"`
+	RepoVersion string            `json:"repo-version,omitempty"`
+	Metadata    map[string]any    `json:"metadata,omitempty"`
+}
+
+// Finished is the content of the finished.json file of a build.
+type Finished struct {
+	Timestamp int64          `json:"timestamp"`
+	Passed    bool           `json:"passed"`
+	Result    string         `json:"result"`
+	Revision  string         `json:"revision,omitempty"`
+	Metadata  map[string]any `json:"metadata,omitempty"`
+}
+
+// GCS reads the artifacts of Prow builds from the public GCS bucket.
+type GCS struct {
+	BaseURL string
+
+	client *http.Client
+}
+
+// GCSOption configures the GCS client.
+type GCSOption func(*GCS)
+
+// WithBucketURL sets the base URL of the bucket, e.g. a httptest server.
+func WithBucketURL(baseURL string) GCSOption {
+	return func(g *GCS) {
+		g.BaseURL = strings.TrimSuffix(baseURL, "/")
+	}
+}
+
+// WithGCSHTTPClient sets the HTTP client used for all requests.
+func WithGCSHTTPClient(client *http.Client) GCSOption {
+	return func(g *GCS) {
+		g.client = client
+	}
+}
+
+func NewGCS(opts ...GCSOption) *GCS {
+	g := &GCS{BaseURL: GCSURL, client: http.DefaultClient}
+	for _, opt := range opts {
+		opt(g)
+	}
+	return g
+}
+
+// BuildPath returns the bucket path of a build from the TestGroup query,
+// e.g. kubernetes-ci-logs/logs/ci-kubernetes-e2e-gce, and its changelist.
+func BuildPath(query, changelist string) string {
+	return strings.Trim(query, "/") + "/" + strings.Trim(changelist, "/")
+}
+
+// BuildPathFromURL returns the bucket path of a Prow job view URL.
+func BuildPathFromURL(prowJobURL string) (string, error) {
+	parsed, err := url.Parse(prowJobURL)
+	if err != nil {
+		return "", err
+	}
+	index := viewRegex.FindStringIndex(parsed.Path)
+	if index == nil {
+		return "", fmt.Errorf("%s is not a Prow job view of a stored build", prowJobURL)
+	}
+	return strings.Trim(parsed.Path[index[1]:], "/"), nil
+}
+
+// Started returns the started.json of the build.
+func (g *GCS) Started(buildPath string) (*Started, error) {
+	var started Started
+	if err := g.readJSON(context.Background(), path.Join(buildPath, "started.json"), &started); err != nil {
+		return nil, err
+	}
+	return &started, nil
+}
+
+// Finished returns the finished.json of the build.
+func (g *GCS) Finished(buildPath string) (*Finished, error) {
+	var finished Finished
+	if err := g.readJSON(context.Background(), path.Join(buildPath, "finished.json"), &finished); err != nil {
+		return nil, err
+	}
+	return &finished, nil
+}
+
+// BuildLog returns the build-log.txt of the build.
+func (g *GCS) BuildLog(buildPath string) (string, error) {
+	data, err := g.Read(path.Join(buildPath, "build-log.txt"))
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// JUnits returns the artifacts/junit_*.xml files of the build by name.
+func (g *GCS) JUnits(buildPath string) (map[string][]byte, error) {
+	bucket, prefix, _ := strings.Cut(path.Join(buildPath, "artifacts", "junit_"), "/")
+	objects, _, err := g.list(context.Background(), bucket, prefix, "")
+	if err != nil {
+		return nil, err
+	}
+	junits := make(map[string][]byte)
+	for _, object := range objects {
+		if !strings.HasSuffix(object, ".xml") || strings.Contains(strings.TrimPrefix(object, prefix), "/") {
+			continue
+		}
+		data, err := g.Read(bucket + "/" + object)
+		if err != nil {
+			return nil, err
+		}
+		junits[path.Base(object)] = data
+	}
+	return junits, nil
+}
+
+// Read returns the content of the object at the bucket/object path.
+func (g *GCS) Read(objectPath string) ([]byte, error) {
+	return g.read(context.Background(), objectPath)
+}
+
+func (g *GCS) read(ctx context.Context, objectPath string) ([]byte, error) {
+	response, err := g.get(ctx, g.BaseURL+"/"+strings.TrimPrefix(objectPath, "/"))
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close() // nolint: errcheck
+	return io.ReadAll(response.Body)
+}
+
+func (g *GCS) readJSON(ctx context.Context, objectPath string, v any) error {
+	data, err := g.read(ctx, objectPath)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("error decoding %s: %w", objectPath, err)
+	}
+	return nil
+}
+
+// list returns the names of the objects starting with prefix, following the
+// pages of the JSON API. With a delimiter, the names up to the delimiter
+// after the prefix are returned as prefixes instead.
+func (g *GCS) list(ctx context.Context, bucket, prefix, delimiter string) (names, prefixes []string, err error) {
+	var pageToken string
+	for {
+		query := url.Values{"prefix": {prefix}, "fields": {"items/name,prefixes,nextPageToken"}}
+		if delimiter != "" {
+			query.Set("delimiter", delimiter)
+		}
+		if pageToken != "" {
+			query.Set("pageToken", pageToken)
+		}
+		response, err := g.get(ctx, fmt.Sprintf("%s/storage/v1/b/%s/o?%s", g.BaseURL, url.PathEscape(bucket), query.Encode()))
+		if err != nil {
+			return nil, nil, err
+		}
+		var page struct {
+			Items []struct {
+				Name string `json:"name"`
+			} `json:"items"`
+			Prefixes      []string `json:"prefixes"`
+			NextPageToken string   `json:"nextPageToken"`
+		}
+		err = json.NewDecoder(response.Body).Decode(&page)
+		response.Body.Close() // nolint: errcheck
+		if err != nil {
+			return nil, nil, fmt.Errorf("error listing %s/%s: %w", bucket, prefix, err)
+		}
+		for _, item := range page.Items {
+			names = append(names, item.Name)
+		}
+		prefixes = append(prefixes, page.Prefixes...)
+		if page.NextPageToken == "" {
+			return names, prefixes, nil
+		}
+		pageToken = page.NextPageToken
+	}
+}
+
+func (g *GCS) get(ctx context.Context, url string) (*http.Response, error) {
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	response, err := g.client.Do(request)
+	if err != nil {
+		return nil, err
+	}
+	if response.StatusCode != http.StatusOK {
+		response.Body.Close() // nolint: errcheck
+		if response.StatusCode == http.StatusNotFound {
+			return nil, fmt.Errorf("error fetching %s: %w", url, ErrObjectNotFound)
+		}
+		return nil, fmt.Errorf("error fetching %s: %s", url, response.Status)
+	}
+	return response, nil
+}
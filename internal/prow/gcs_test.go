@@ -0,0 +1,120 @@
+package prow
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const buildPath = "kubernetes-ci-logs/logs/ci-kubernetes-e2e-gce/1900000000000000000"
+
+const jobPath = "kubernetes-ci-logs/logs/ci-kubernetes-e2e-gce"
+
+func newBucket(t *testing.T) *GCS {
+	objects := map[string]string{
+		"/" + buildPath + "/started.json":                    `{"timestamp": 1700000000, "repo-version": "v1.34.0-alpha.1+abcdef0"}`,
+		"/" + buildPath + "/finished.json":                   `{"timestamp": 1700003600, "passed": false, "result": "FAILURE", "revision": "abcdef0"}`,
+		"/" + buildPath + "/build-log.txt":                   "step 1\nstep 2 failed\n",
+		"/" + buildPath + "/artifacts/junit_01.xml":          `<testsuites></testsuites>`,
+		"/" + buildPath + "/artifacts/junit_02.xml":          `<testsuite></testsuite>`,
+		"/" + jobPath + "/1899999999999999999/started.json":  `{"timestamp": 1699990000}`,
+		"/" + jobPath + "/1899999999999999999/finished.json": `{"timestamp": 1699993000, "passed": true, "revision": "v1.34.0-alpha.1+1234567"}`,
+		"/" + jobPath + "/1900000000000000001/started.json":  `{"timestamp": 1700010000, "repo-version": "v1.34.0-alpha.1+fedcba9"}`,
+		"/" + jobPath + "/1899999999999999998/started.json":  `{"timestamp": `,
+		"/" + jobPath + "/latest-build.txt":                  "1900000000000000001\n",
+	}
+	// the job listings return the builds starting with the prefix
+	builds := []string{"999", "1899999999999999998", "1900000000000000000", "1899999999999999999", "1900000000000000001", "tmp"}
+	// listings are keyed by prefix and page token
+	listings := map[string]string{
+		"logs/ci-kubernetes-e2e-gce/1900000000000000000/artifacts/junit_": `{"items": [
+			{"name": "logs/ci-kubernetes-e2e-gce/1900000000000000000/artifacts/junit_01.xml"},
+			{"name": "logs/ci-kubernetes-e2e-gce/1900000000000000000/artifacts/junit_runner.log"}],
+			"nextPageToken": "next"}`,
+		"logs/ci-kubernetes-e2e-gce/1900000000000000000/artifacts/junit_next": `{"items": [
+			{"name": "logs/ci-kubernetes-e2e-gce/1900000000000000000/artifacts/junit_02.xml"}]}`,
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/storage/v1/b/kubernetes-ci-logs/o" {
+			prefix := r.URL.Query().Get("prefix")
+			if jobPrefix := "logs/ci-kubernetes-e2e-gce/"; r.URL.Query().Get("delimiter") == "/" && strings.HasPrefix(prefix, jobPrefix) {
+				var prefixes []string
+				for _, build := range builds {
+					if strings.HasPrefix(jobPrefix+build, prefix) {
+						prefixes = append(prefixes, fmt.Sprintf("%q", jobPrefix+build+"/"))
+					}
+				}
+				fmt.Fprintf(w, `{"prefixes": [%s]}`, strings.Join(prefixes, ", ")) // nolint: errcheck
+				return
+			}
+			listing, ok := listings[prefix+r.URL.Query().Get("pageToken")]
+			assert.True(t, ok, "unexpected listing %s", r.URL.RawQuery)
+			fmt.Fprint(w, listing) // nolint: errcheck
+			return
+		}
+		content, ok := objects[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		fmt.Fprint(w, content) // nolint: errcheck
+	}))
+	t.Cleanup(server.Close)
+	return NewGCS(WithBucketURL(server.URL + "/"))
+}
+
+func TestGCS(t *testing.T) {
+	gcs := newBucket(t)
+
+	started, err := gcs.Started(buildPath)
+	assert.NoError(t, err)
+	assert.Equal(t, &Started{Timestamp: 1700000000, RepoVersion: "v1.34.0-alpha.1+abcdef0"}, started)
+
+	finished, err := gcs.Finished(buildPath)
+	assert.NoError(t, err)
+	assert.Equal(t, &Finished{Timestamp: 1700003600, Result: "FAILURE", Revision: "abcdef0"}, finished)
+
+	buildLog, err := gcs.BuildLog(buildPath)
+	assert.NoError(t, err)
+	assert.Equal(t, "step 1\nstep 2 failed\n", buildLog)
+
+	junits, err := gcs.JUnits(buildPath)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string][]byte{
+		"junit_01.xml": []byte(`<testsuites></testsuites>`),
+		"junit_02.xml": []byte(`<testsuite></testsuite>`),
+	}, junits)
+
+	_, err = gcs.Started(buildPath + "0")
+	assert.ErrorIs(t, err, ErrObjectNotFound)
+}
+
+func TestBuildPath(t *testing.T) {
+	assert.Equal(t, buildPath, BuildPath("kubernetes-ci-logs/logs/ci-kubernetes-e2e-gce/", "1900000000000000000"))
+
+	tests := []struct {
+		url      string
+		expected string
+		wantErr  bool
+	}{
+		{url: "https://prow.k8s.io/view/gs/" + buildPath, expected: buildPath},
+		{url: "https://prow.k8s.io/view/gs/" + buildPath + "/", expected: buildPath},
+		{url: "https://prow.example.com/view/s3/" + buildPath, expected: buildPath},
+		{url: "https://prow.k8s.io/job-history/gs/kubernetes-ci-logs/logs/ci-kubernetes-e2e-gce", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.url, func(t *testing.T) {
+			got, err := BuildPathFromURL(tt.url)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, got)
+		})
+	}
+}
@@ -0,0 +1,206 @@
+package prow
+
+import (
+	"context"
+	"errors"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultJobHistoryBuilds is the number of builds read by JobHistory by default.
+const DefaultJobHistoryBuilds = 20
+
+// maxBuildReaders is the number of builds read in parallel by JobHistory.
+const maxBuildReaders = 8
+
+// Results of the builds, as written in finished.json. PENDING is set on
+// builds without a finished.json.
+const (
+	ResultSuccess = "SUCCESS"
+	ResultFailure = "FAILURE"
+	ResultAborted = "ABORTED"
+	ResultError   = "ERROR"
+	ResultPending = "PENDING"
+)
+
+// Build is a run of a Prow job.
+type Build struct {
+	ID       string
+	Started  time.Time
+	Duration time.Duration
+	Result   string
+	Commit   string
+}
+
+// JobPathFromURL returns the bucket path of the job of a Prow job view URL.
+func JobPathFromURL(prowJobURL string) (string, error) {
+	buildPath, err := BuildPathFromURL(prowJobURL)
+	if err != nil {
+		return "", err
+	}
+	return path.Dir(buildPath), nil
+}
+
+// JobHistory returns the latest builds of the job at the bucket path, e.g.
+// kubernetes-ci-logs/logs/ci-kubernetes-e2e-gce, the newest first. The builds
+// are read by a bounded pool of workers, the ones that cannot be read are
+// skipped unless none can.
+func (g *GCS) JobHistory(ctx context.Context, jobPath string, limit int) ([]Build, error) {
+	if limit <= 0 {
+		limit = DefaultJobHistoryBuilds
+	}
+	bucket, prefix, _ := strings.Cut(strings.Trim(jobPath, "/")+"/", "/")
+	ids, err := g.buildIDs(ctx, bucket, prefix, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		wg      sync.WaitGroup
+		builds  = make([]*Build, len(ids))
+		errs    = make([]error, len(ids))
+		indexes = make(chan int)
+	)
+	for range min(maxBuildReaders, len(ids)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				builds[i], errs[i] = g.build(ctx, bucket+"/"+prefix+ids[i], ids[i])
+			}
+		}()
+	}
+	for i := range ids {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+
+	var (
+		history  []Build
+		firstErr error
+	)
+	for i, build := range builds {
+		if errs[i] != nil {
+			if firstErr == nil {
+				firstErr = errs[i]
+			}
+			continue
+		}
+		if build != nil {
+			history = append(history, *build)
+		}
+	}
+	if len(history) == 0 && firstErr != nil {
+		return nil, firstErr
+	}
+	return history, nil
+}
+
+// buildIDs returns the IDs of the latest builds of the job, the newest first.
+// Rather than paging through the whole job, the listing starts from the
+// builds sharing the leading digits of latest-build.txt and widens one digit
+// at a time until it holds limit builds.
+func (g *GCS) buildIDs(ctx context.Context, bucket, prefix string, limit int) ([]string, error) {
+	data, err := g.read(ctx, bucket+"/"+prefix+"latest-build.txt")
+	if err != nil && !errors.Is(err, ErrObjectNotFound) {
+		return nil, err
+	}
+	latest := strings.TrimSpace(string(data))
+	if !isBuildID(latest) {
+		latest = ""
+	}
+
+	for digits := max(len(latest)-1, 0); ; digits-- {
+		_, prefixes, err := g.list(ctx, bucket, prefix+latest[:digits], "/")
+		if err != nil {
+			return nil, err
+		}
+		var ids []string
+		for _, buildPrefix := range prefixes {
+			if id := path.Base(buildPrefix); isBuildID(id) {
+				ids = append(ids, id)
+			}
+		}
+		if len(ids) < limit && digits > 0 {
+			continue
+		}
+
+		// build IDs are increasing numbers, longer IDs are newer
+		sort.Slice(ids, func(i, j int) bool {
+			if len(ids[i]) != len(ids[j]) {
+				return len(ids[i]) > len(ids[j])
+			}
+			return ids[i] > ids[j]
+		})
+		if len(ids) > limit {
+			ids = ids[:limit]
+		}
+		return ids, nil
+	}
+}
+
+// build reads the started.json and finished.json of a build, it returns nil
+// for builds that have not started yet.
+func (g *GCS) build(ctx context.Context, buildPath, id string) (*Build, error) {
+	var started Started
+	err := g.readJSON(ctx, path.Join(buildPath, "started.json"), &started)
+	if errors.Is(err, ErrObjectNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	build := &Build{
+		ID:      id,
+		Started: time.Unix(started.Timestamp, 0),
+		Result:  ResultPending,
+		Commit:  versionCommit(started.RepoVersion),
+	}
+
+	var finished Finished
+	err = g.readJSON(ctx, path.Join(buildPath, "finished.json"), &finished)
+	if errors.Is(err, ErrObjectNotFound) {
+		return build, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	build.Result = finished.Result
+	if build.Result == "" {
+		build.Result = ResultFailure
+		if finished.Passed {
+			build.Result = ResultSuccess
+		}
+	}
+	if finished.Timestamp >= started.Timestamp {
+		build.Duration = time.Duration(finished.Timestamp-started.Timestamp) * time.Second
+	}
+	if finished.Revision != "" {
+		build.Commit = versionCommit(finished.Revision)
+	}
+	return build, nil
+}
+
+// versionCommit returns the commit of a version such as v1.34.0-alpha.1.5+abcdef0.
+func versionCommit(version string) string {
+	if _, commit, found := strings.Cut(version, "+"); found {
+		return commit
+	}
+	return version
+}
+
+func isBuildID(id string) bool {
+	if id == "" {
+		return false
+	}
+	for _, r := range id {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
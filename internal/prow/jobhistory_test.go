@@ -0,0 +1,45 @@
+package prow
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJobHistory(t *testing.T) {
+	gcs := newBucket(t)
+
+	builds, err := gcs.JobHistory(context.Background(), jobPath, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, []Build{
+		{ID: "1900000000000000001", Started: time.Unix(1700010000, 0), Result: ResultPending, Commit: "fedcba9"},
+		{ID: "1900000000000000000", Started: time.Unix(1700000000, 0), Duration: time.Hour, Result: ResultFailure, Commit: "abcdef0"},
+		{ID: "1899999999999999999", Started: time.Unix(1699990000, 0), Duration: 50 * time.Minute, Result: ResultSuccess, Commit: "1234567"},
+	}, builds)
+
+	builds, err = gcs.JobHistory(context.Background(), jobPath+"/", 1)
+	assert.NoError(t, err)
+	assert.Len(t, builds, 1)
+	assert.Equal(t, "1900000000000000001", builds[0].ID)
+
+	// the build without a readable started.json is skipped
+	builds, err = gcs.JobHistory(context.Background(), jobPath, 4)
+	assert.NoError(t, err)
+	assert.Len(t, builds, 3)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = gcs.JobHistory(ctx, jobPath, 0)
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestJobPathFromURL(t *testing.T) {
+	got, err := JobPathFromURL("https://prow.k8s.io/view/gs/" + buildPath)
+	assert.NoError(t, err)
+	assert.Equal(t, jobPath, got)
+
+	_, err = JobPathFromURL("https://testgrid.k8s.io/sig-release-master-blocking")
+	assert.Error(t, err)
+}
@@ -0,0 +1,136 @@
+package prow
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"sigs.k8s.io/signalhound/api/v1alpha1"
+)
+
+// ErrTestNotFound is returned when no failing test case matches the test name.
+var ErrTestNotFound = errors.New("test not found in the JUnit artifacts")
+
+var (
+	// locationRegex matches Go source locations, as printed by Ginkgo and go test.
+	locationRegex = regexp.MustCompile(`[\w./-]+\.go:\d+`)
+	// junitPrefixRegex strips the Ginkgo node type of JUnit test case names.
+	junitPrefixRegex = regexp.MustCompile(`^(Kubernetes e2e suite[.:]\s*)?\[It\]\s*`)
+	junitSpacesRegex = regexp.MustCompile(`\s+`)
+)
+
+// JUnitTestCase is a test case of a JUnit file.
+type JUnitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Time      float64       `xml:"time,attr"`
+	Failure   *JUnitFailure `xml:"failure"`
+	Skipped   *struct{}     `xml:"skipped"`
+	SystemOut string        `xml:"system-out"`
+	SystemErr string        `xml:"system-err"`
+}
+
+// JUnitFailure is the failure of a test case.
+type JUnitFailure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Text    string `xml:",chardata"`
+}
+
+type junitSuite struct {
+	Suites    []junitSuite    `xml:"testsuite"`
+	TestCases []JUnitTestCase `xml:"testcase"`
+}
+
+// ParseJUnit returns the test cases of a JUnit file, with either a
+// testsuites or a testsuite root element.
+func ParseJUnit(data []byte) ([]JUnitTestCase, error) {
+	var root junitSuite
+	if err := xml.Unmarshal(data, &root); err != nil {
+		return nil, fmt.Errorf("error parsing JUnit: %w", err)
+	}
+	var cases []JUnitTestCase
+	var walk func(suite junitSuite)
+	walk = func(suite junitSuite) {
+		cases = append(cases, suite.TestCases...)
+		for _, child := range suite.Suites {
+			walk(child)
+		}
+	}
+	walk(root)
+	return cases, nil
+}
+
+// FindFailure returns the failure of the test in the JUnit files, matching
+// the test case name exactly first, then by containment, since TestGrid drops
+// the suite and tag prefixes of Ginkgo names.
+func FindFailure(junits map[string][]byte, testName string) (*v1alpha1.JUnitResult, error) {
+	names := make([]string, 0, len(junits))
+	for name := range junits {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	wanted := normalizeCaseName(testName)
+	var partial *JUnitTestCase
+	for _, name := range names {
+		cases, err := ParseJUnit(junits[name])
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+		for i := range cases {
+			testCase := &cases[i]
+			if testCase.Failure == nil {
+				continue
+			}
+			caseName := normalizeCaseName(testCase.Name)
+			if caseName == wanted || normalizeCaseName(testCase.ClassName+"."+testCase.Name) == wanted {
+				return testCase.Result(), nil
+			}
+			if partial == nil && wanted != "" && strings.Contains(caseName, wanted) {
+				partial = testCase
+			}
+		}
+	}
+	if partial == nil {
+		return nil, fmt.Errorf("%w: %s", ErrTestNotFound, testName)
+	}
+	return partial.Result(), nil
+}
+
+// Result converts the test case to its API representation.
+func (c *JUnitTestCase) Result() *v1alpha1.JUnitResult {
+	result := &v1alpha1.JUnitResult{
+		Duration:  metav1.Duration{Duration: time.Duration(math.Round(c.Time*1000)) * time.Millisecond},
+		SystemOut: strings.TrimSpace(c.SystemOut),
+	}
+	if c.Failure != nil {
+		result.FailureMessage = strings.TrimSpace(c.Failure.Text)
+		if result.FailureMessage == "" {
+			result.FailureMessage = strings.TrimSpace(c.Failure.Message)
+		}
+		result.Location = locationRegex.FindString(c.Failure.Text + "\n" + c.SystemErr)
+	}
+	return result
+}
+
+// TestFailure reads the JUnit artifacts of the build and returns the failure of the test.
+func (g *GCS) TestFailure(buildPath, testName string) (*v1alpha1.JUnitResult, error) {
+	junits, err := g.JUnits(buildPath)
+	if err != nil {
+		return nil, err
+	}
+	return FindFailure(junits, testName)
+}
+
+func normalizeCaseName(name string) string {
+	name = junitPrefixRegex.ReplaceAllString(strings.TrimSpace(name), "")
+	return junitSpacesRegex.ReplaceAllString(name, " ")
+}
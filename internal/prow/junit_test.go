@@ -0,0 +1,103 @@
+package prow
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"sigs.k8s.io/signalhound/api/v1alpha1"
+)
+
+const ginkgoJUnit = `<?xml version="1.0" encoding="UTF-8"?>
+<testsuites tests="3" failures="1">
+  <testsuite name="Kubernetes e2e suite" tests="3" failures="1">
+    <testcase name="[It] [sig-node] Pods should be submitted and removed [Conformance]" classname="Kubernetes e2e suite" time="12.3456">
+      <failure message="[FAILED] timed out waiting for the condition" type="failed">[FAILED] timed out waiting for the condition
+In [It] at: k8s.io/kubernetes/test/e2e/node/pods.go:123 @ 01/02/25 10:00:00.000
+</failure>
+      <system-out>STEP: creating the pod</system-out>
+    </testcase>
+    <testcase name="[It] [sig-node] Pods should be updated" classname="Kubernetes e2e suite" time="1"></testcase>
+    <testcase name="[It] [sig-storage] Volumes should mount" classname="Kubernetes e2e suite" time="0">
+      <skipped message="skipped"></skipped>
+    </testcase>
+  </testsuite>
+</testsuites>`
+
+const goTestJUnit = `<testsuite name="k8s.io/kubernetes/pkg/kubelet" tests="1" failures="1">
+  <testcase name="TestSyncPod" classname="k8s.io/kubernetes/pkg/kubelet" time="0.5">
+    <failure message="Failed" type=""></failure>
+    <system-err>    kubelet_test.go:42: pod was not synced</system-err>
+  </testcase>
+</testsuite>`
+
+func TestParseJUnit(t *testing.T) {
+	cases, err := ParseJUnit([]byte(ginkgoJUnit))
+	assert.NoError(t, err)
+	assert.Len(t, cases, 3)
+	assert.NotNil(t, cases[0].Failure)
+	assert.Nil(t, cases[1].Failure)
+	assert.NotNil(t, cases[2].Skipped)
+
+	cases, err = ParseJUnit([]byte(goTestJUnit))
+	assert.NoError(t, err)
+	assert.Len(t, cases, 1)
+
+	_, err = ParseJUnit([]byte("<testsuite>"))
+	assert.Error(t, err)
+}
+
+func TestFindFailure(t *testing.T) {
+	junits := map[string][]byte{"junit_01.xml": []byte(ginkgoJUnit), "junit_02.xml": []byte(goTestJUnit)}
+
+	tests := []struct {
+		name     string
+		testName string
+		expected *v1alpha1.JUnitResult
+		wantErr  error
+	}{
+		{
+			name:     "ginkgo name stripped by testgrid",
+			testName: "Pods should be submitted and removed [Conformance]",
+			expected: &v1alpha1.JUnitResult{
+				FailureMessage: "[FAILED] timed out waiting for the condition\nIn [It] at: k8s.io/kubernetes/test/e2e/node/pods.go:123 @ 01/02/25 10:00:00.000",
+				Location:       "k8s.io/kubernetes/test/e2e/node/pods.go:123",
+				Duration:       metav1.Duration{Duration: 12346 * time.Millisecond},
+				SystemOut:      "STEP: creating the pod",
+			},
+		},
+		{
+			name:     "go test name with package",
+			testName: "k8s.io/kubernetes/pkg/kubelet.TestSyncPod",
+			expected: &v1alpha1.JUnitResult{
+				FailureMessage: "Failed",
+				Location:       "kubelet_test.go:42",
+				Duration:       metav1.Duration{Duration: 500 * time.Millisecond},
+			},
+		},
+		{
+			name:     "passing test",
+			testName: "Pods should be updated",
+			wantErr:  ErrTestNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := FindFailure(junits, tt.testName)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func TestGCSTestFailure(t *testing.T) {
+	result, err := newBucket(t).TestFailure(buildPath, "missing")
+	assert.ErrorIs(t, err, ErrTestNotFound)
+	assert.Nil(t, result)
+}
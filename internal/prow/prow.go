@@ -1,163 +1,89 @@
 package prow
 
 import (
-	"encoding/json"
-	"fmt"
-	"io"
+	"context"
 	"net/http"
-	"net/url"
-	"strings"
 
-	"golang.org/x/net/html"
+	"sigs.k8s.io/signalhound/internal/links"
+	"sigs.k8s.io/signalhound/internal/logscan"
 )
 
-var URL = "https://prow.k8s.io"
+var URL = links.DefaultProwURL
 
 type Prow struct {
 	ProwURL  string
 	JunitURL string
 	Error    string
+
+	client *http.Client
+	gcs    *GCS
 }
 
-// BuildLog holds the build log
+// BuildLog holds the failure excerpts of the build log
 type BuildLog struct {
-	Error   string
-	LensURL string
+	Excerpts []logscan.Excerpt
+}
+
+// Text prints the excerpts with their line ranges.
+func (b *BuildLog) Text() string {
+	return logscan.Text(b.Excerpts)
 }
 
 type ProwInterface interface {
 	GetSpyGlassLens() (*BuildLog, error)
+	GetJobHistory(ctx context.Context, limit int) ([]Build, error)
 }
 
-func NewProw(prowUrl string) ProwInterface {
-	if prowUrl == "" {
-		prowUrl = URL
+// Option configures the Prow client.
+type Option func(*Prow)
+
+// WithHTTPClient sets the HTTP client used for all requests.
+func WithHTTPClient(client *http.Client) Option {
+	return func(p *Prow) {
+		p.client = client
 	}
-	return &Prow{ProwURL: prowUrl}
 }
 
-// GetSpyGlassLens returns a jUnit object with parsed error from the build
-// spyglass pane. This requires multiple requests to scrape JS files
-// rendered in the main page, and used later for next pages.
-func (t *Prow) GetSpyGlassLens() (*BuildLog, error) {
-	body, err := getHTTPResponse(t.ProwURL)
-	if err != nil {
-		return nil, err
+// WithGCS sets the client reading the raw build artifacts.
+func WithGCS(gcs *GCS) Option {
+	return func(p *Prow) {
+		p.gcs = gcs
 	}
+}
 
-	// Extract Junit Iframe data and build the next URL.
-	var lensURL string
-	if lensURL, err = extractBuildLensURL(body); err != nil {
-		return nil, err
+func NewProw(prowUrl string, opts ...Option) ProwInterface {
+	if prowUrl == "" {
+		prowUrl = URL
 	}
-
-	// Extract Lens build body from the glass pane request.
-	if body, err = getHTTPResponse(lensURL); err != nil {
-		return nil, err
+	p := &Prow{ProwURL: prowUrl, client: http.DefaultClient}
+	for _, opt := range opts {
+		opt(p)
 	}
-	buildLog, err := extractBuildLogs(body)
-	if err != nil {
-		return nil, err
+	if p.gcs == nil {
+		p.gcs = NewGCS(WithGCSHTTPClient(p.client))
 	}
-	buildLog.LensURL = lensURL
-	return buildLog, nil
+	return p
 }
 
-// extractBuildLogs returns the buildlog serialization error logs from
-// build log glass pane.
-func extractBuildLogs(body io.Reader) (*BuildLog, error) {
-	var chunks, errors = 0, ""
-
-	// Parse the HTML body
-	doc, err := html.Parse(body)
+// GetSpyGlassLens returns the failure excerpts of the raw build log stored
+// along the job artifacts.
+func (t *Prow) GetSpyGlassLens() (*BuildLog, error) {
+	buildPath, err := BuildPathFromURL(t.ProwURL)
 	if err != nil {
 		return nil, err
 	}
-
-	var crawler func(*html.Node)
-	crawler = func(node *html.Node) {
-		if node.Type == html.TextNode && node.Data != "" && !strings.Contains(node.Data, "\n") && len(node.Data) > 10 {
-			if node.Parent.Data == "span" && chunks == 1 {
-				errors += fmt.Sprintf("%s\n", node.Data)
-			} else if node.Type == html.TextNode && node.Data == "expand_less" {
-				chunks += 1
-			}
-		}
-		for child := node.FirstChild; child != nil; child = child.NextSibling {
-			crawler(child)
-		}
-	}
-	crawler(doc)
-	return &BuildLog{Error: errors}, nil
-}
-
-// extractBuildLensURL returns the final URL for next phase build lens logs extraction.
-func extractBuildLensURL(body io.Reader) (string, error) {
-	var (
-		content  string
-		buildLen string
-	)
-
-	// Parse the HTML body
-	doc, err := html.Parse(body)
+	log, err := t.gcs.BuildLog(buildPath)
 	if err != nil {
-		return "", err
-	}
-
-	var crawler func(*html.Node)
-	crawler = func(node *html.Node) {
-		// Search the first script data and save on a local variable
-		if node.Data == "script" && node.FirstChild != nil && strings.Contains(node.FirstChild.Data, "lensArtifacts") {
-			content = node.FirstChild.Data
-		}
-		// Set the iframe index for extracting the script data
-		if node.DataAtom == 195590 && node.Type == html.ElementNode {
-			if getAttributeValue(node.Attr, "data-lens-name") == "buildlog" {
-				buildLen = getAttributeValue(node.Attr, "data-lens-index")
-				return
-			}
-		}
-		for child := node.FirstChild; child != nil; child = child.NextSibling {
-			crawler(child)
-		}
-	}
-	crawler(doc)
-
-	// Extract JSON path from script lensArtifacts
-	var jsonData map[string][]string
-	artifacts := GetRegexParameter(`var lensArtifacts = (?<JSON>{"\d+":\[(?:"[^"]+",?)*\](?:,\s*"\d+":\[(?:"[^"]+",?)*\])*\});`, content)["JSON"]
-	if err := json.Unmarshal([]byte(artifacts), &jsonData); err != nil {
-		return "", err
-	}
-
-	// Extract artifacts list from specific lensArtifacts buildlog index.
-	var artifactsList []byte
-	if artifactsList, err = json.Marshal(jsonData[buildLen]); err != nil {
-		return "", err
-	}
-
-	// Returns the final buildlog URL rendered in the iframe.
-	gsURL := GetRegexParameter(`var src = "(?<URL>[^"]+)"`, content)["URL"]
-	data := fmt.Sprintf(`{"artifacts": %s,"index": %s,"src": "%s"}`, artifactsList, buildLen, gsURL)
-	return URL + "/spyglass/lens/buildlog/iframe?req=" + url.QueryEscape(data), nil
-}
-
-func getHTTPResponse(url string) (io.Reader, error) {
-	var (
-		response *http.Response
-		err      error
-	)
-	if response, err = http.Get(url); err != nil {
 		return nil, err
 	}
-	return response.Body, nil
+	return &BuildLog{Excerpts: logscan.Scan(log)}, nil
 }
 
-func getAttributeValue(attrs []html.Attribute, name string) string {
-	for _, attr := range attrs {
-		if attr.Key == name {
-			return attr.Val
-		}
+// GetJobHistory returns the latest builds of the job of the Prow URL.
+func (t *Prow) GetJobHistory(ctx context.Context, limit int) ([]Build, error) {
+	jobPath, err := JobPathFromURL(t.ProwURL)
+	if err != nil {
+		return nil, err
 	}
-	return ""
+	return t.gcs.JobHistory(ctx, jobPath, limit)
 }
@@ -0,0 +1,53 @@
+package report
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"sigs.k8s.io/signalhound/api/v1alpha1"
+	"sigs.k8s.io/signalhound/internal/cluster"
+	"sigs.k8s.io/signalhound/internal/truncate"
+)
+
+// maxClusterTextLength caps the cluster text printed as its title.
+const maxClusterTextLength = 200
+
+// Write prints the failing and flaky tabs with their tests, followed by the
+// failure clusters so a single issue can be filed per root cause.
+func Write(w io.Writer, tabs []*v1alpha1.DashboardTab, clusters []cluster.Cluster) error {
+	var out strings.Builder
+	for _, tab := range tabs {
+		state := tab.TabState
+		if tab.Stale {
+			state += " STALE"
+		}
+		fmt.Fprintf(&out, "%s %s (%d tests)\n", state, tab.BoardHash, len(tab.TestRuns))
+		for _, test := range tab.TestRuns {
+			fmt.Fprintf(&out, "  %s failures=%d flakes=%d score=%d\n",
+				test.TestName, test.FailureCount, test.FlakeCount, test.FlakinessScore)
+		}
+	}
+
+	if len(clusters) > 0 {
+		fmt.Fprintf(&out, "\nFailure clusters\n")
+	}
+	for i, c := range clusters {
+		fmt.Fprintf(&out, "\n#%d %d tests in %d jobs: %s\n", i+1, len(c.Members), len(c.Jobs()), Title(c.Text))
+		for _, member := range c.Members {
+			fmt.Fprintf(&out, "  - %s: %s\n", member.Tab.BoardHash, member.Test.TestName)
+		}
+	}
+
+	_, err := io.WriteString(w, out.String())
+	return err
+}
+
+// Title returns the first line of the cluster text, shortened for display.
+func Title(text string) string {
+	title, _, _ := strings.Cut(text, "\n")
+	if len(title) > maxClusterTextLength {
+		title = truncate.Head(title, maxClusterTextLength) + "..."
+	}
+	return title
+}
@@ -0,0 +1,43 @@
+package report
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/assert"
+	"sigs.k8s.io/signalhound/api/v1alpha1"
+	"sigs.k8s.io/signalhound/internal/cluster"
+)
+
+func TestWrite(t *testing.T) {
+	tab := &v1alpha1.DashboardTab{
+		BoardHash: "sig-release-master-blocking#gce",
+		TabState:  v1alpha1.FAILING_STATUS,
+		TestRuns: []v1alpha1.TestResult{
+			{TestName: "a", FailureCount: 3, ErrorMessage: "timed out"},
+			{TestName: "b", FailureCount: 2, ErrorMessage: "timed out"},
+		},
+	}
+	tabs := []*v1alpha1.DashboardTab{tab}
+
+	var out bytes.Buffer
+	assert.NoError(t, Write(&out, tabs, cluster.Build(tabs, cluster.DefaultThreshold)))
+	assert.Equal(t, `FAILING sig-release-master-blocking#gce (2 tests)
+  a failures=3 flakes=0 score=0
+  b failures=2 flakes=0 score=0
+
+Failure clusters
+
+#1 2 tests in 1 jobs: timed out
+  - sig-release-master-blocking#gce: a
+  - sig-release-master-blocking#gce: b
+`, out.String())
+}
+
+func TestTitle(t *testing.T) {
+	assert.Equal(t, "first line", Title("first line\nsecond line"))
+	assert.Equal(t, strings.Repeat("x", maxClusterTextLength)+"...", Title(strings.Repeat("x", maxClusterTextLength+1)))
+	assert.True(t, utf8.ValidString(Title("x"+strings.Repeat("é", maxClusterTextLength))))
+}
@@ -0,0 +1,116 @@
+package sig
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	"sigs.k8s.io/yaml"
+)
+
+// tagRegex matches the SIG tags of e2e test names, e.g. [sig-node].
+var tagRegex = regexp.MustCompile(`\[sig-([a-z0-9-]+)\]`)
+
+// FromTestName returns the SIG tagged in the test name, without the sig- prefix.
+func FromTestName(testName string) string {
+	if match := tagRegex.FindStringSubmatch(testName); match != nil {
+		return match[1]
+	}
+	return ""
+}
+
+// Rule maps a job name or test name regular expression to a SIG.
+type Rule struct {
+	// Job is a regular expression matched against the Prow job name.
+	Job string `json:"job,omitempty"`
+	// Test is a regular expression matched against the test name.
+	Test string `json:"test,omitempty"`
+	// Sig is the owning SIG, e.g. node or sig-node.
+	Sig string `json:"sig"`
+}
+
+// Mapping is the ordered list of rules read from the mapping file.
+type Mapping struct {
+	Rules []Rule `json:"rules"`
+
+	jobs  []*regexp.Regexp
+	tests []*regexp.Regexp
+}
+
+// LoadMapping reads and compiles the mapping file at path.
+func LoadMapping(path string) (*Mapping, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading sig mapping file: %v", err)
+	}
+	var mapping Mapping
+	if err = yaml.UnmarshalStrict(data, &mapping); err != nil {
+		return nil, fmt.Errorf("error parsing sig mapping file %s: %v", path, err)
+	}
+	if err = mapping.compile(); err != nil {
+		return nil, fmt.Errorf("error in sig mapping file %s: %v", path, err)
+	}
+	return &mapping, nil
+}
+
+// NewMapping compiles the rules into a mapping.
+func NewMapping(rules []Rule) (*Mapping, error) {
+	mapping := &Mapping{Rules: rules}
+	if err := mapping.compile(); err != nil {
+		return nil, err
+	}
+	return mapping, nil
+}
+
+func (m *Mapping) compile() error {
+	m.jobs = make([]*regexp.Regexp, len(m.Rules))
+	m.tests = make([]*regexp.Regexp, len(m.Rules))
+	for i, rule := range m.Rules {
+		if rule.Sig == "" {
+			return fmt.Errorf("rule %d has no sig", i)
+		}
+		if rule.Job == "" && rule.Test == "" {
+			return fmt.Errorf("rule for sig %s has neither job nor test", rule.Sig)
+		}
+		var err error
+		if rule.Job != "" {
+			if m.jobs[i], err = regexp.Compile(rule.Job); err != nil {
+				return err
+			}
+		}
+		if rule.Test != "" {
+			if m.tests[i], err = regexp.Compile(rule.Test); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Detect returns the SIG owning the test. The tag in the test name wins,
+// otherwise the first matching rule is used. A nil mapping only looks at tags.
+func (m *Mapping) Detect(jobName, testName string) string {
+	if sig := FromTestName(testName); sig != "" {
+		return sig
+	}
+	if m == nil {
+		return ""
+	}
+	for i, rule := range m.Rules {
+		if m.jobs[i] != nil && !m.jobs[i].MatchString(jobName) {
+			continue
+		}
+		if m.tests[i] != nil && !m.tests[i].MatchString(testName) {
+			continue
+		}
+		return normalize(rule.Sig)
+	}
+	return ""
+}
+
+// normalize strips the sig- or sig/ prefix so the value fits a /sig command.
+func normalize(sig string) string {
+	sig = strings.ToLower(strings.TrimSpace(sig))
+	return strings.TrimPrefix(strings.TrimPrefix(sig, "sig-"), "sig/")
+}
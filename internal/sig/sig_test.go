@@ -0,0 +1,79 @@
+package sig
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Detect(t *testing.T) {
+	mapping, err := NewMapping([]Rule{
+		{Job: "^ci-kubernetes-e2e-storage", Sig: "sig-storage"},
+		{Job: "^ci-kubernetes-node-", Test: "Overall", Sig: "node"},
+		{Test: "kubetest2", Sig: "sig/testing"},
+	})
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		mapping  *Mapping
+		job      string
+		test     string
+		expected string
+	}{
+		{
+			name:     "tag in the test name",
+			mapping:  mapping,
+			job:      "ci-kubernetes-e2e-storage-gce",
+			test:     "Kubernetes e2e suite.[It] [sig-network] Services should serve endpoints",
+			expected: "network",
+		},
+		{
+			name:     "job rule",
+			mapping:  mapping,
+			job:      "ci-kubernetes-e2e-storage-gce",
+			test:     "ci-kubernetes-e2e-storage-gce.Overall",
+			expected: "storage",
+		},
+		{
+			name:     "job and test rule",
+			mapping:  mapping,
+			job:      "ci-kubernetes-node-kubelet",
+			test:     "ci-kubernetes-node-kubelet.Overall",
+			expected: "node",
+		},
+		{
+			name:     "test rule",
+			mapping:  mapping,
+			job:      "ci-kubernetes-build",
+			test:     "kubetest2.Test",
+			expected: "testing",
+		},
+		{
+			name: "no match",
+			job:  "ci-kubernetes-build",
+			test: "ci-kubernetes-build.Overall",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.mapping.Detect(tt.job, tt.test))
+		})
+	}
+}
+
+func Test_LoadMapping(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "sigs.yaml")
+	assert.NoError(t, os.WriteFile(path, []byte("rules:\n- job: \"^ci-kubernetes-e2e-windows\"\n  sig: windows\n"), 0o600))
+
+	mapping, err := LoadMapping(path)
+	assert.NoError(t, err)
+	assert.Equal(t, "windows", mapping.Detect("ci-kubernetes-e2e-windows-containerd", "Overall"))
+
+	assert.NoError(t, os.WriteFile(path, []byte("rules:\n- job: \"(\"\n  sig: windows\n"), 0o600))
+	_, err = LoadMapping(path)
+	assert.Error(t, err)
+}
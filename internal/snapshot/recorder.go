@@ -0,0 +1,53 @@
+package snapshot
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"sync"
+)
+
+// Recorder is an http.RoundTripper keeping a copy of every successful GET
+// response body, used to embed the raw TestGrid payloads in a snapshot.
+type Recorder struct {
+	Base http.RoundTripper
+
+	mu       sync.Mutex
+	payloads map[string][]byte
+}
+
+// NewRecorder returns a recorder on top of the base transport.
+func NewRecorder(base http.RoundTripper) *Recorder {
+	return &Recorder{Base: base, payloads: map[string][]byte{}}
+}
+
+// RoundTrip implements http.RoundTripper.
+func (r *Recorder) RoundTrip(request *http.Request) (*http.Response, error) {
+	response, err := r.Base.RoundTrip(request)
+	if err != nil || request.Method != http.MethodGet || response.StatusCode != http.StatusOK {
+		return response, err
+	}
+
+	body, err := io.ReadAll(response.Body)
+	response.Body.Close() // nolint: errcheck
+	if err != nil {
+		return nil, err
+	}
+	response.Body = io.NopCloser(bytes.NewReader(body))
+
+	r.mu.Lock()
+	r.payloads[request.URL.String()] = body
+	r.mu.Unlock()
+	return response, nil
+}
+
+// Payloads returns the recorded bodies indexed by request URL.
+func (r *Recorder) Payloads() map[string][]byte {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	payloads := make(map[string][]byte, len(r.payloads))
+	for url, body := range r.payloads {
+		payloads[url] = body
+	}
+	return payloads
+}
@@ -0,0 +1,86 @@
+package snapshot
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"sigs.k8s.io/signalhound/api/v1alpha1"
+)
+
+// Version is the current snapshot archive format version.
+const Version = 1
+
+// Snapshot is the serialized state of the boards at a given time.
+type Snapshot struct {
+	// Version of the archive format, used to reject unknown files.
+	Version int `json:"version"`
+
+	// CreatedAt is the time the snapshot was taken.
+	CreatedAt time.Time `json:"created_at"`
+
+	// Dashboards are the TestGrid dashboard names that were scraped.
+	Dashboards []string `json:"dashboards,omitempty"`
+
+	// Tabs are the filtered dashboard tabs as rendered in the TUI.
+	Tabs []*v1alpha1.DashboardTab `json:"tabs"`
+
+	// Payloads are the raw TestGrid responses indexed by request URL.
+	Payloads map[string][]byte `json:"payloads,omitempty"`
+}
+
+// New returns a snapshot of the tabs taken now.
+func New(dashboards []string, tabs []*v1alpha1.DashboardTab, payloads map[string][]byte) *Snapshot {
+	return &Snapshot{
+		Version:    Version,
+		CreatedAt:  time.Now().UTC(),
+		Dashboards: dashboards,
+		Tabs:       tabs,
+		Payloads:   payloads,
+	}
+}
+
+// Save writes the snapshot as a gzip compressed JSON archive.
+func Save(path string, snapshot *Snapshot) (err error) {
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("error creating snapshot file: %v", err)
+	}
+	defer func() {
+		if closeErr := file.Close(); err == nil {
+			err = closeErr
+		}
+	}()
+
+	writer := gzip.NewWriter(file)
+	if err = json.NewEncoder(writer).Encode(snapshot); err != nil {
+		return fmt.Errorf("error encoding snapshot: %v", err)
+	}
+	return writer.Close()
+}
+
+// Load reads a snapshot archive written by Save.
+func Load(path string) (*Snapshot, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("error opening snapshot file: %v", err)
+	}
+	defer file.Close() // nolint: errcheck
+
+	reader, err := gzip.NewReader(file)
+	if err != nil {
+		return nil, fmt.Errorf("error reading snapshot archive %s: %v", path, err)
+	}
+	defer reader.Close() // nolint: errcheck
+
+	var snapshot Snapshot
+	if err := json.NewDecoder(reader).Decode(&snapshot); err != nil {
+		return nil, fmt.Errorf("error decoding snapshot %s: %v", path, err)
+	}
+	if snapshot.Version != Version {
+		return nil, fmt.Errorf("unsupported snapshot version %d, expected %d", snapshot.Version, Version)
+	}
+	return &snapshot, nil
+}
@@ -0,0 +1,73 @@
+package snapshot
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"sigs.k8s.io/signalhound/api/v1alpha1"
+)
+
+func Test_SaveLoad(t *testing.T) {
+	tabs := []*v1alpha1.DashboardTab{
+		{
+			TabName:   "gce-cos-master-default",
+			BoardHash: "sig-release-master-blocking#gce-cos-master-default",
+			TabState:  v1alpha1.FAILING_STATUS,
+			TestRuns:  []v1alpha1.TestResult{{TestName: "ci-kubernetes-e2e.Overall", ErrorMessage: "F"}},
+		},
+	}
+	payloads := map[string][]byte{"https://testgrid.k8s.io/sig-release-master-blocking/summary": []byte(`{}`)}
+
+	path := filepath.Join(t.TempDir(), "board.snap")
+	assert.NoError(t, Save(path, New([]string{"sig-release-master-blocking"}, tabs, payloads)))
+
+	snap, err := Load(path)
+	assert.NoError(t, err)
+	assert.Equal(t, Version, snap.Version)
+	assert.Equal(t, tabs, snap.Tabs)
+	assert.Equal(t, payloads, snap.Payloads)
+	assert.Equal(t, []string{"sig-release-master-blocking"}, snap.Dashboards)
+}
+
+func Test_LoadUnknownVersion(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "board.snap")
+	file, err := os.Create(path)
+	assert.NoError(t, err)
+	writer := gzip.NewWriter(file)
+	assert.NoError(t, json.NewEncoder(writer).Encode(Snapshot{Version: Version + 1}))
+	assert.NoError(t, writer.Close())
+	assert.NoError(t, file.Close())
+
+	_, err = Load(path)
+	assert.ErrorContains(t, err, "unsupported snapshot version")
+}
+
+func Test_Recorder(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/missing" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write([]byte(r.URL.Path)) // nolint
+	}))
+	defer server.Close()
+
+	recorder := NewRecorder(http.DefaultTransport)
+	client := &http.Client{Transport: recorder}
+	for _, path := range []string{"/summary", "/table", "/missing"} {
+		response, err := client.Get(server.URL + path)
+		assert.NoError(t, err)
+		response.Body.Close() // nolint: errcheck
+	}
+
+	assert.Equal(t, map[string][]byte{
+		server.URL + "/summary": []byte("/summary"),
+		server.URL + "/table":   []byte("/table"),
+	}, recorder.Payloads())
+}
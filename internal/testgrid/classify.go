@@ -0,0 +1,103 @@
+package testgrid
+
+import (
+	"regexp"
+	"slices"
+	"strings"
+
+	"sigs.k8s.io/signalhound/api/v1alpha1"
+)
+
+var (
+	// setupRowRegex matches the cluster bring-up and build steps.
+	setupRowRegex = regexp.MustCompile(`(?i)^(kubetest2?\.)?(up|isup|build|extract|stage|check version skew|node tests setup)$|^\[(synchronized)?beforesuite\]`)
+	// teardownRowRegex matches the cluster tear down and log collection steps.
+	teardownRowRegex = regexp.MustCompile(`(?i)^(kubetest2?\.)?(down|dumpclusterlogs.*|deferred teardown|teardown.*|log dump.*)$|^\[(synchronized|report)?aftersuite\]`)
+	// infraRowRegex matches the job-level rows aggregating a whole run.
+	infraRowRegex = regexp.MustCompile(`(?i)(^|\.)(overall|pod|timeout)$|^kubetest2?\.(test|timeout)$`)
+	// infraMessageRegex matches failures of the CI infrastructure itself.
+	infraMessageRegex = regexp.MustCompile(`(?i)pod got deleted unexpectedly|pod pending timeout|job execution failed: pod|boskos|quota exceeded|exceeded quota|no space left on device|failed to acquire`)
+)
+
+// Classify tags a TestGrid row as an infra, setup, test or teardown step
+// from its name, and from its messages and short texts for test rows failing
+// because of the CI infrastructure.
+func Classify(name string, messages, shortTexts []string) string {
+	name = NormalizeTestName(name)
+	switch {
+	case setupRowRegex.MatchString(name):
+		return v1alpha1.SETUP_CATEGORY
+	case teardownRowRegex.MatchString(name):
+		return v1alpha1.TEARDOWN_CATEGORY
+	case infraRowRegex.MatchString(name):
+		return v1alpha1.INFRA_CATEGORY
+	}
+	for _, text := range slices.Concat(messages, shortTexts) {
+		if infraMessageRegex.MatchString(text) {
+			return v1alpha1.INFRA_CATEGORY
+		}
+	}
+	return v1alpha1.TEST_CATEGORY
+}
+
+// aggregateCategoryOrder ranks the categories an aggregate row takes from the
+// other rows failing in the same column, a test failure explains it best.
+var aggregateCategoryOrder = map[string]int{
+	v1alpha1.TEST_CATEGORY:     3,
+	v1alpha1.SETUP_CATEGORY:    2,
+	v1alpha1.TEARDOWN_CATEGORY: 1,
+}
+
+// isAggregateRow reports whether the row aggregates a whole run, like Overall,
+// Pod or kubetest2.Test.
+func isAggregateRow(name string) bool {
+	return infraRowRegex.MatchString(NormalizeTestName(name))
+}
+
+// columnCategories returns for each column the category of the rows failing or
+// flaking in it that do not aggregate the run, empty when none did.
+func columnCategories(testGroup *TestGroup, columns int) []string {
+	categories := make([]string, columns)
+	for _, test := range testGroup.Tests {
+		if isAggregateRow(test.Name) {
+			continue
+		}
+		category := Classify(test.Name, test.Messages, test.ShortTexts)
+		if aggregateCategoryOrder[category] == 0 {
+			continue
+		}
+		for column, status := range test.DecodeStatuses(columns) {
+			if (status.IsFailure() || status.IsFlaky()) && aggregateCategoryOrder[category] > aggregateCategoryOrder[categories[column]] {
+				categories[column] = category
+			}
+		}
+	}
+	return categories
+}
+
+// classifyRow classifies a row of the test group. An aggregate row is an infra
+// failure only when no other row failed in the column of its latest failure,
+// otherwise it sums up those failures and takes their category.
+func classifyRow(test *Test, column int, categories []string) string {
+	category := Classify(test.Name, test.Messages, test.ShortTexts)
+	if category == v1alpha1.INFRA_CATEGORY && isAggregateRow(test.Name) &&
+		column >= 0 && column < len(categories) && categories[column] != "" {
+		return categories[column]
+	}
+	return category
+}
+
+// FilterByCategory drops the tests of the tab outside the given categories,
+// no categories keeps every test.
+func FilterByCategory(tab *v1alpha1.DashboardTab, categories []string) {
+	if len(categories) == 0 {
+		return
+	}
+	tests := tab.TestRuns[:0]
+	for _, test := range tab.TestRuns {
+		if slices.ContainsFunc(categories, func(category string) bool { return strings.EqualFold(category, test.Category) }) {
+			tests = append(tests, test)
+		}
+	}
+	tab.TestRuns = tests
+}
@@ -0,0 +1,86 @@
+package testgrid
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"sigs.k8s.io/signalhound/api/v1alpha1"
+)
+
+func TestClassify(t *testing.T) {
+	tests := []struct {
+		name     string
+		row      string
+		messages []string
+		expected string
+	}{
+		{name: "overall row", row: "ci-kubernetes-e2e-gce.Overall", expected: v1alpha1.INFRA_CATEGORY},
+		{name: "pod row", row: "Pod", expected: v1alpha1.INFRA_CATEGORY},
+		{name: "kubetest2 test step", row: "kubetest2.Test", expected: v1alpha1.INFRA_CATEGORY},
+		{name: "kubetest up", row: "kubetest.Up", expected: v1alpha1.SETUP_CATEGORY},
+		{name: "build step", row: "kubetest2.Build", expected: v1alpha1.SETUP_CATEGORY},
+		{name: "before suite", row: "Kubernetes e2e suite.[SynchronizedBeforeSuite]", expected: v1alpha1.SETUP_CATEGORY},
+		{name: "kubetest2 down", row: "kubetest2.Down", expected: v1alpha1.TEARDOWN_CATEGORY},
+		{name: "log dump", row: "kubetest.DumpClusterLogs", expected: v1alpha1.TEARDOWN_CATEGORY},
+		{name: "after suite", row: "Kubernetes e2e suite.[SynchronizedAfterSuite]", expected: v1alpha1.TEARDOWN_CATEGORY},
+		{name: "e2e test", row: "Kubernetes e2e suite.[It] [sig-node] Pods should be updated", expected: v1alpha1.TEST_CATEGORY},
+		{
+			name:     "e2e test failing on infra",
+			row:      "Kubernetes e2e suite.[It] [sig-node] Pods should be updated",
+			messages: []string{"", "Job execution failed: Pod got deleted unexpectedly"},
+			expected: v1alpha1.INFRA_CATEGORY,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, Classify(tt.row, tt.messages, nil))
+		})
+	}
+}
+
+func TestClassifyAggregateRows(t *testing.T) {
+	// the newest column comes first: the e2e test failed in the second run only,
+	// the Up step in the third one only
+	testGroup := &TestGroup{Tests: []Test{
+		{Name: "Overall", Statuses: []Statuses{{Count: 3, Value: int(StatusFail)}}},
+		{Name: "Kubernetes e2e suite.[It] [sig-node] Pods should be updated", Statuses: []Statuses{
+			{Count: 1, Value: int(StatusPass)}, {Count: 1, Value: int(StatusFail)}, {Count: 1, Value: int(StatusPass)},
+		}},
+		{Name: "kubetest.Up", Statuses: []Statuses{{Count: 2, Value: int(StatusPass)}, {Count: 1, Value: int(StatusFail)}}},
+	}}
+	categories := columnCategories(testGroup, 3)
+	assert.Equal(t, []string{"", v1alpha1.TEST_CATEGORY, v1alpha1.SETUP_CATEGORY}, categories)
+
+	tests := []struct {
+		name     string
+		column   int
+		expected string
+	}{
+		{name: "no other row failed", column: 0, expected: v1alpha1.INFRA_CATEGORY},
+		{name: "a test failed in the run", column: 1, expected: v1alpha1.TEST_CATEGORY},
+		{name: "the setup failed in the run", column: 2, expected: v1alpha1.SETUP_CATEGORY},
+		{name: "no failure", column: -1, expected: v1alpha1.INFRA_CATEGORY},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, classifyRow(&testGroup.Tests[0], tt.column, categories))
+		})
+	}
+	assert.Equal(t, v1alpha1.TEST_CATEGORY, classifyRow(&testGroup.Tests[1], 1, categories))
+}
+
+func TestFilterByCategory(t *testing.T) {
+	tests := []v1alpha1.TestResult{
+		{TestName: "Overall", Category: v1alpha1.INFRA_CATEGORY},
+		{TestName: "e2e", Category: v1alpha1.TEST_CATEGORY},
+	}
+
+	tab := &v1alpha1.DashboardTab{TestRuns: append([]v1alpha1.TestResult{}, tests...)}
+	FilterByCategory(tab, nil)
+	assert.Equal(t, tests, tab.TestRuns)
+
+	FilterByCategory(tab, []string{"test"})
+	assert.Equal(t, []v1alpha1.TestResult{tests[1]}, tab.TestRuns)
+}
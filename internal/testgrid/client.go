@@ -0,0 +1,162 @@
+package testgrid
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"sigs.k8s.io/signalhound/internal/links"
+	"sigs.k8s.io/signalhound/internal/sig"
+)
+
+const (
+	defaultTimeout    = 30 * time.Second
+	defaultMaxRetries = 3
+	defaultBackoff    = 500 * time.Millisecond
+	defaultUserAgent  = "signalhound"
+)
+
+var (
+	// ErrNotFound is returned when TestGrid does not know the dashboard or tab.
+	ErrNotFound = errors.New("testgrid resource not found")
+	// ErrRateLimited is returned when TestGrid keeps answering 429 after all retries.
+	ErrRateLimited = errors.New("testgrid rate limit exceeded")
+	// ErrUpstream is returned for server errors or unexpected status codes.
+	ErrUpstream = errors.New("testgrid upstream error")
+)
+
+// Option configures the TestGrid client.
+type Option func(*TestGrid)
+
+// WithHTTPClient sets the HTTP client used for all requests.
+func WithHTTPClient(client *http.Client) Option {
+	return func(t *TestGrid) {
+		t.client = client
+	}
+}
+
+// WithTimeout sets the timeout of a single request attempt, 0 disables it.
+func WithTimeout(timeout time.Duration) Option {
+	return func(t *TestGrid) {
+		t.timeout = timeout
+	}
+}
+
+// WithRetry sets the number of retries on 5xx and 429 answers and the initial
+// backoff, doubled after every attempt.
+func WithRetry(maxRetries int, backoff time.Duration) Option {
+	return func(t *TestGrid) {
+		t.maxRetries = maxRetries
+		t.backoff = backoff
+	}
+}
+
+// WithUserAgent sets the User-Agent header sent to TestGrid.
+func WithUserAgent(userAgent string) Option {
+	return func(t *TestGrid) {
+		t.userAgent = userAgent
+	}
+}
+
+// WithSigMapping sets the fallback rules used to find the SIG of tests
+// without a [sig-*] tag in their name.
+func WithSigMapping(mapping *sig.Mapping) Option {
+	return func(t *TestGrid) {
+		t.sigMapping = mapping
+	}
+}
+
+// WithLinks sets the base URLs of the tab, Prow job and triage links, the
+// TestGrid URL of the client is kept when unset.
+func WithLinks(config links.Config) Option {
+	return func(t *TestGrid) {
+		t.links = t.links.Override(config)
+	}
+}
+
+// WithStaleMultiplier sets how many run intervals a tab can go without a new
+// run before being flagged as stale, 0 disables the detection.
+func WithStaleMultiplier(multiplier int) Option {
+	return func(t *TestGrid) {
+		t.staleMultiplier = multiplier
+	}
+}
+
+// get requests the URL and returns the response body, retrying with
+// exponential backoff while the server answers with 5xx or 429.
+func (t *TestGrid) get(ctx context.Context, url string) ([]byte, error) {
+	backoff := t.backoff
+	for attempt := 0; ; attempt++ {
+		data, status, retryAfter, err := t.doGet(ctx, url)
+		if err == nil {
+			return data, nil
+		}
+		retryable := status >= http.StatusInternalServerError || status == http.StatusTooManyRequests
+		if attempt >= t.maxRetries || !retryable {
+			return nil, err
+		}
+
+		wait := backoff
+		if retryAfter > 0 {
+			wait = retryAfter
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(wait):
+		}
+		backoff *= 2
+	}
+}
+
+// doGet runs a single request attempt, it returns the response status code
+// and the Retry-After delay when the server sets one.
+func (t *TestGrid) doGet(ctx context.Context, url string) ([]byte, int, time.Duration, error) {
+	if t.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, t.timeout)
+		defer cancel()
+	}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, 0, 0, err
+	}
+	request.Header.Set("User-Agent", t.userAgent)
+
+	response, err := t.client.Do(request)
+	if err != nil {
+		return nil, 0, 0, err
+	}
+	defer response.Body.Close() // nolint: errcheck
+
+	status := response.StatusCode
+	switch {
+	case status == http.StatusNotFound:
+		return nil, status, 0, fmt.Errorf("%w: %s", ErrNotFound, url)
+	case status == http.StatusTooManyRequests:
+		retryAfter := parseRetryAfter(response.Header.Get("Retry-After"))
+		return nil, status, retryAfter, fmt.Errorf("%w: %s", ErrRateLimited, url)
+	case status != http.StatusOK:
+		return nil, status, 0, fmt.Errorf("%w: %s returned %s", ErrUpstream, url, response.Status)
+	}
+
+	data, err := io.ReadAll(response.Body)
+	if err != nil {
+		return nil, status, 0, fmt.Errorf("error parsing body response: %v", err)
+	}
+	return data, status, 0, nil
+}
+
+// parseRetryAfter returns the delay from a Retry-After header in seconds.
+func parseRetryAfter(value string) time.Duration {
+	seconds, err := strconv.Atoi(value)
+	if err != nil || seconds < 0 {
+		return 0
+	}
+	return time.Duration(seconds) * time.Second
+}
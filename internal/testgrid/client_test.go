@@ -0,0 +1,82 @@
+package testgrid
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ClientGet(t *testing.T) {
+	tests := []struct {
+		name          string
+		statuses      []int
+		expectedErr   error
+		expectedCalls int
+	}{
+		{
+			name:          "retry server errors until success",
+			statuses:      []int{http.StatusInternalServerError, http.StatusBadGateway, http.StatusOK},
+			expectedCalls: 3,
+		},
+		{
+			name:          "not found is not retried",
+			statuses:      []int{http.StatusNotFound},
+			expectedErr:   ErrNotFound,
+			expectedCalls: 1,
+		},
+		{
+			name:          "rate limited after all retries",
+			statuses:      []int{http.StatusTooManyRequests, http.StatusTooManyRequests, http.StatusTooManyRequests},
+			expectedErr:   ErrRateLimited,
+			expectedCalls: 3,
+		},
+		{
+			name:          "client errors are not retried",
+			statuses:      []int{http.StatusForbidden},
+			expectedErr:   ErrUpstream,
+			expectedCalls: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls int
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "signalhound-test", r.Header.Get("User-Agent"))
+				w.WriteHeader(tt.statuses[calls])
+				w.Write([]byte("{}")) // nolint
+				calls++
+			}))
+			defer server.Close()
+
+			tg := NewTestGrid(server.URL,
+				WithHTTPClient(server.Client()),
+				WithRetry(2, time.Millisecond),
+				WithUserAgent("signalhound-test"),
+			)
+			data, err := tg.get(context.Background(), server.URL)
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, "{}", string(data))
+			}
+			assert.Equal(t, tt.expectedCalls, calls)
+		})
+	}
+}
+
+func Test_ClientTimeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	}))
+	defer server.Close()
+
+	tg := NewTestGrid(server.URL, WithTimeout(10*time.Millisecond), WithRetry(0, 0))
+	_, err := tg.get(context.Background(), server.URL)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
@@ -0,0 +1,59 @@
+package testgrid
+
+import (
+	"regexp"
+	"strings"
+)
+
+// commitRegex matches an abbreviated or full git commit hash.
+var commitRegex = regexp.MustCompile(`^[0-9a-f]{7,40}$`)
+
+// commitColumn returns the index of the custom column holding the kubernetes
+// commit, the infra commit column is ignored. Without headers the first
+// custom column is used.
+func (tg *TestGroup) commitColumn() int {
+	if len(tg.ColumnHeaderNames) == 0 {
+		return 0
+	}
+	for i, header := range tg.ColumnHeaderNames {
+		if strings.EqualFold(header, "commit") {
+			return i
+		}
+	}
+	for i, header := range tg.ColumnHeaderNames {
+		header = strings.ToLower(header)
+		if strings.Contains(header, "commit") && !strings.Contains(header, "infra") {
+			return i
+		}
+	}
+	return -1
+}
+
+// ColumnCommit returns the kubernetes commit the column was built from, or an
+// empty string when unknown. Version strings such as v1.34.0-alpha.1.20+0a1b2c3d4e5f
+// are reduced to their commit.
+func (tg *TestGroup) ColumnCommit(index int) string {
+	column := tg.commitColumn()
+	if column < 0 || index < 0 || index >= len(tg.CustomColumns) || column >= len(tg.CustomColumns[index]) {
+		return ""
+	}
+	commit := tg.CustomColumns[index][column]
+	if _, after, found := strings.Cut(commit, "+"); found {
+		commit = after
+	}
+	if !commitRegex.MatchString(commit) {
+		return ""
+	}
+	return commit
+}
+
+// firstFailureSince returns the column of the oldest failure more recent than
+// the last pass, -1 when the test never passed or did not fail since.
+func firstFailureSince(statuses []TestStatus, lastPass int) int {
+	for i := lastPass - 1; i >= 0; i-- {
+		if statuses[i].IsFailure() {
+			return i
+		}
+	}
+	return -1
+}
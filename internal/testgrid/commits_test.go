@@ -0,0 +1,74 @@
+package testgrid
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"sigs.k8s.io/signalhound/api/v1alpha1"
+	"sigs.k8s.io/signalhound/internal/links"
+)
+
+func TestColumnCommit(t *testing.T) {
+	tests := []struct {
+		name      string
+		testGroup TestGroup
+		expected  []string
+	}{
+		{
+			name: "commit header with versions",
+			testGroup: TestGroup{
+				ColumnHeaderNames: []string{"Infra-Commit", "Commit"},
+				CustomColumns: [][]string{
+					{"1234567", "v1.35.0-alpha.1.20+0a1b2c3d4e5f"},
+					{"1234567", "9f8e7d6c5b4a"},
+					{"1234567", "unknown"},
+				},
+			},
+			expected: []string{"0a1b2c3d4e5f", "9f8e7d6c5b4a", ""},
+		},
+		{
+			name: "first column without headers",
+			testGroup: TestGroup{
+				CustomColumns: [][]string{{"abcdef0"}, {}},
+			},
+			expected: []string{"abcdef0", ""},
+		},
+		{
+			name: "no commit header",
+			testGroup: TestGroup{
+				ColumnHeaderNames: []string{"Infra-Commit"},
+				CustomColumns:     [][]string{{"abcdef0"}},
+			},
+			expected: []string{""},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for i, expected := range tt.expected {
+				assert.Equal(t, expected, tt.testGroup.ColumnCommit(i))
+			}
+		})
+	}
+}
+
+func TestFilterTabTestsCommits(t *testing.T) {
+	testGroup := &TestGroup{
+		Query:             "kubernetes-ci-logs/logs/ci-kubernetes-e2e",
+		Timestamps:        []int64{4000, 3000, 2000, 1000},
+		Changelists:       []string{"4", "3", "2", "1"},
+		ColumnHeaderNames: []string{"Commit"},
+		CustomColumns:     [][]string{{"dddddddd"}, {"cccccccc"}, {"bbbbbbbb"}, {"aaaaaaaa"}},
+		Tests: []Test{
+			{
+				Name:     "ci-kubernetes-e2e.Overall",
+				Statuses: []Statuses{{Count: 2, Value: 12}, {Count: 1, Value: 0}, {Count: 1, Value: 1}},
+			},
+		},
+	}
+
+	tests := filterTabTests(testGroup, v1alpha1.FAILING_STATUS, 1, 0, nil, links.Default())
+	assert.Len(t, tests, 1)
+	assert.Equal(t, "aaaaaaaa", tests[0].LastPassCommit)
+	assert.Equal(t, "cccccccc", tests[0].FirstFailureCommit)
+}
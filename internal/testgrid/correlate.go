@@ -0,0 +1,73 @@
+package testgrid
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+
+	"sigs.k8s.io/signalhound/api/v1alpha1"
+)
+
+var (
+	// testNamePrefixRegex matches the suite and Ginkgo node prefixes jobs add to the same test.
+	testNamePrefixRegex = regexp.MustCompile(`^(?:Kubernetes e2e suite\.|\[k8s\.io\]\s*)*(?:\[It\]\s*)?`)
+	spacesRegex         = regexp.MustCompile(`\s+`)
+)
+
+// AffectedJob is a tab where a correlated test is failing or flaking.
+type AffectedJob struct {
+	Tab  *v1alpha1.DashboardTab
+	Test v1alpha1.TestResult
+}
+
+// CorrelatedTest is a test grouped across all the tabs it shows up in.
+type CorrelatedTest struct {
+	// Name is the normalized test name shared by the jobs.
+	Name string
+	Jobs []AffectedJob
+}
+
+// State returns FAILING when the test fails in any of the jobs, FLAKY otherwise.
+func (c *CorrelatedTest) State() string {
+	for _, job := range c.Jobs {
+		if job.Tab.TabState == v1alpha1.FAILING_STATUS {
+			return v1alpha1.FAILING_STATUS
+		}
+	}
+	return v1alpha1.FLAKY_STATUS
+}
+
+// NormalizeTestName strips the suite prefixes and extra whitespace so the
+// same test reported by different jobs gets the same name.
+func NormalizeTestName(name string) string {
+	name = strings.TrimSpace(name)
+	name = testNamePrefixRegex.ReplaceAllString(name, "")
+	return spacesRegex.ReplaceAllString(name, " ")
+}
+
+// CorrelateTests groups the tests of all tabs by normalized name. Tests
+// affecting more jobs come first, ties are ordered by name.
+func CorrelateTests(tabs []*v1alpha1.DashboardTab) []CorrelatedTest {
+	var correlated []CorrelatedTest
+	index := map[string]int{}
+	for _, tab := range tabs {
+		for _, test := range tab.TestRuns {
+			name := NormalizeTestName(test.TestName)
+			i, ok := index[name]
+			if !ok {
+				i = len(correlated)
+				index[name] = i
+				correlated = append(correlated, CorrelatedTest{Name: name})
+			}
+			correlated[i].Jobs = append(correlated[i].Jobs, AffectedJob{Tab: tab, Test: test})
+		}
+	}
+
+	sort.SliceStable(correlated, func(i, j int) bool {
+		if len(correlated[i].Jobs) != len(correlated[j].Jobs) {
+			return len(correlated[i].Jobs) > len(correlated[j].Jobs)
+		}
+		return correlated[i].Name < correlated[j].Name
+	})
+	return correlated
+}
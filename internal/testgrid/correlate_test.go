@@ -0,0 +1,68 @@
+package testgrid
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"sigs.k8s.io/signalhound/api/v1alpha1"
+)
+
+func TestNormalizeTestName(t *testing.T) {
+	tests := []struct {
+		name     string
+		testName string
+		expected string
+	}{
+		{
+			name:     "suite prefix",
+			testName: "Kubernetes e2e suite.[It] [sig-node] Pods should be updated",
+			expected: "[sig-node] Pods should be updated",
+		},
+		{
+			name:     "extra whitespace",
+			testName: " [sig-node]  Pods should be updated ",
+			expected: "[sig-node] Pods should be updated",
+		},
+		{
+			name:     "job level row is left alone",
+			testName: "ci-kubernetes-e2e-gce.Overall",
+			expected: "ci-kubernetes-e2e-gce.Overall",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, NormalizeTestName(tt.testName))
+		})
+	}
+}
+
+func TestCorrelateTests(t *testing.T) {
+	gce := &v1alpha1.DashboardTab{
+		BoardHash: "sig-release-master-blocking#gce",
+		TabState:  v1alpha1.FLAKY_STATUS,
+		TestRuns: []v1alpha1.TestResult{
+			{TestName: "Kubernetes e2e suite.[It] [sig-node] Pods should be updated"},
+			{TestName: "ci-kubernetes-e2e-gce.Overall"},
+		},
+	}
+	kind := &v1alpha1.DashboardTab{
+		BoardHash: "sig-release-master-blocking#kind",
+		TabState:  v1alpha1.FAILING_STATUS,
+		TestRuns: []v1alpha1.TestResult{
+			{TestName: "[sig-node] Pods should be updated"},
+		},
+	}
+
+	correlated := CorrelateTests([]*v1alpha1.DashboardTab{gce, kind})
+	assert.Len(t, correlated, 2)
+
+	assert.Equal(t, "[sig-node] Pods should be updated", correlated[0].Name)
+	assert.Len(t, correlated[0].Jobs, 2)
+	assert.Equal(t, gce, correlated[0].Jobs[0].Tab)
+	assert.Equal(t, kind, correlated[0].Jobs[1].Tab)
+	assert.Equal(t, v1alpha1.FAILING_STATUS, correlated[0].State())
+
+	assert.Equal(t, "ci-kubernetes-e2e-gce.Overall", correlated[1].Name)
+	assert.Equal(t, v1alpha1.FLAKY_STATUS, correlated[1].State())
+}
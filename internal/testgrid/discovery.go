@@ -0,0 +1,127 @@
+package testgrid
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// DefaultDashboardGroup is the TestGrid group holding the release boards.
+const DefaultDashboardGroup = "sig-release"
+
+const dashboardGroupURL = "%s/api/v1/dashboard-groups/%s"
+
+// releaseDashboardRegex matches the release team boards of master and of release branches.
+var releaseDashboardRegex = regexp.MustCompile(`^sig-release-(?<VERSION>master|\d+\.\d+)-(blocking|informing)$`)
+
+// DashboardGroup serializes the content from the testgrid dashboard group endpoint
+type DashboardGroup struct {
+	Dashboards []struct {
+		Name string `json:"name"`
+		Link string `json:"link"`
+	} `json:"dashboards"`
+}
+
+// ListDashboardGroup returns the names of the dashboards in a TestGrid group.
+func (t *TestGrid) ListDashboardGroup(ctx context.Context, group string) ([]string, error) {
+	data, err := t.get(ctx, fmt.Sprintf(dashboardGroupURL, t.URL, cleanHTMLCharacters(group)))
+	if err != nil {
+		return nil, fmt.Errorf("error fetching testgrid dashboard group: %w", err)
+	}
+
+	var dashboardGroup DashboardGroup
+	if err = json.Unmarshal(data, &dashboardGroup); err != nil {
+		return nil, fmt.Errorf("error unmarshaling body response: %v", err)
+	}
+
+	names := make([]string, 0, len(dashboardGroup.Dashboards))
+	for _, dashboard := range dashboardGroup.Dashboards {
+		names = append(names, dashboard.Name)
+	}
+	return names, nil
+}
+
+// DiscoverReleaseDashboards lists the group and returns the master boards
+// followed by the boards of the N most recent release branches.
+func (t *TestGrid) DiscoverReleaseDashboards(ctx context.Context, group string, releases int) ([]string, error) {
+	names, err := t.ListDashboardGroup(ctx, group)
+	if err != nil {
+		return nil, err
+	}
+	return SelectReleaseDashboards(names, releases), nil
+}
+
+// SelectReleaseDashboards filters the release team boards from names, keeping
+// master and the N most recent release branches ordered by version, newest first.
+func SelectReleaseDashboards(names []string, releases int) []string {
+	type releaseDashboard struct {
+		name         string
+		master       bool
+		major, minor int
+	}
+
+	var dashboards []releaseDashboard
+	versions := map[[2]int]bool{}
+	for _, name := range names {
+		version := releaseDashboardRegex.FindStringSubmatch(name)
+		if version == nil {
+			continue
+		}
+		dashboard := releaseDashboard{name: name, master: version[1] == "master"}
+		if !dashboard.master {
+			dashboard.major, dashboard.minor = parseVersion(version[1])
+			versions[[2]int{dashboard.major, dashboard.minor}] = true
+		}
+		dashboards = append(dashboards, dashboard)
+	}
+
+	// keep only the N most recent versions
+	var sorted [][2]int
+	for version := range versions {
+		sorted = append(sorted, version)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i][0] != sorted[j][0] {
+			return sorted[i][0] > sorted[j][0]
+		}
+		return sorted[i][1] > sorted[j][1]
+	})
+	recent := map[[2]int]bool{}
+	for i := 0; i < releases && i < len(sorted); i++ {
+		recent[sorted[i]] = true
+	}
+
+	sort.SliceStable(dashboards, func(i, j int) bool {
+		a, b := dashboards[i], dashboards[j]
+		if a.master != b.master {
+			return a.master
+		}
+		if a.major != b.major {
+			return a.major > b.major
+		}
+		if a.minor != b.minor {
+			return a.minor > b.minor
+		}
+		return a.name < b.name
+	})
+
+	var selected []string
+	for _, dashboard := range dashboards {
+		if dashboard.master || recent[[2]int{dashboard.major, dashboard.minor}] {
+			selected = append(selected, dashboard.name)
+		}
+	}
+	return selected
+}
+
+// parseVersion returns the major and minor numbers of a 1.XX version.
+func parseVersion(version string) (int, int) {
+	majorText, minorText, _ := strings.Cut(version, ".")
+	major, _ := strconv.Atoi(majorText)
+	minor, _ := strconv.Atoi(minorText)
+	return major, minor
+}
@@ -0,0 +1,71 @@
+package testgrid
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSelectReleaseDashboards(t *testing.T) {
+	names := []string{
+		"sig-release-1.32-blocking", "sig-release-1.33-informing", "sig-release-master-informing",
+		"sig-release-1.9-blocking", "sig-release-1.34-blocking", "sig-release-1.33-blocking",
+		"sig-release-master-blocking", "sig-release-misc", "sig-release-1.34-informing",
+		"sig-release-releng-blocking",
+	}
+
+	tests := []struct {
+		name     string
+		releases int
+		expected []string
+	}{
+		{
+			name:     "master only",
+			releases: 0,
+			expected: []string{"sig-release-master-blocking", "sig-release-master-informing"},
+		},
+		{
+			name:     "two most recent releases",
+			releases: 2,
+			expected: []string{
+				"sig-release-master-blocking", "sig-release-master-informing",
+				"sig-release-1.34-blocking", "sig-release-1.34-informing",
+				"sig-release-1.33-blocking", "sig-release-1.33-informing",
+			},
+		},
+		{
+			name:     "versions are compared numerically",
+			releases: 4,
+			expected: []string{
+				"sig-release-master-blocking", "sig-release-master-informing",
+				"sig-release-1.34-blocking", "sig-release-1.34-informing",
+				"sig-release-1.33-blocking", "sig-release-1.33-informing",
+				"sig-release-1.32-blocking", "sig-release-1.9-blocking",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, SelectReleaseDashboards(names, tt.releases))
+		})
+	}
+}
+
+func TestDiscoverReleaseDashboards(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v1/dashboard-groups/sig-release", r.URL.Path)
+		payload := `{"dashboards":[{"name":"sig-release-master-blocking","link":"/sig-release-master-blocking"},` +
+			`{"name":"sig-release-1.34-blocking","link":"/sig-release-1.34-blocking"}]}`
+		w.Write([]byte(payload)) // nolint
+	}))
+	defer server.Close()
+
+	tg := NewTestGrid(server.URL)
+	dashboards, err := tg.DiscoverReleaseDashboards(context.Background(), DefaultDashboardGroup, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"sig-release-master-blocking", "sig-release-1.34-blocking"}, dashboards)
+}
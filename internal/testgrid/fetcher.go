@@ -0,0 +1,105 @@
+package testgrid
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+
+	"sigs.k8s.io/signalhound/api/v1alpha1"
+)
+
+// DefaultConcurrency is the number of tabs fetched in parallel when none is set.
+const DefaultConcurrency = 8
+
+// TabRequest is a dashboard tab to be fetched with its filtering thresholds.
+type TabRequest struct {
+	Summary    v1alpha1.DashboardSummary
+	MinFailure int
+	MinFlake   int
+}
+
+// TabError records a tab that could not be fetched.
+type TabError struct {
+	Dashboard string
+	Tab       string
+	Err       error
+}
+
+func (e TabError) Error() string {
+	return fmt.Sprintf("error fetching table %s#%s: %v", e.Dashboard, e.Tab, e.Err)
+}
+
+func (e TabError) Unwrap() error {
+	return e.Err
+}
+
+// ProgressFunc is called every time a tab finishes, successfully or not.
+type ProgressFunc func(done, total int)
+
+// FetchTabsTests fetches the tests of all requested tabs using a bounded pool of
+// workers. Tabs are returned sorted by BoardHash, so the output does not depend
+// on the order requests complete; tabs that failed are reported in the error
+// list and skipped from the result.
+func (t *TestGrid) FetchTabsTests(ctx context.Context, requests []TabRequest, concurrency int, progress ProgressFunc) ([]*v1alpha1.DashboardTab, []TabError) {
+	if concurrency <= 0 {
+		concurrency = DefaultConcurrency
+	}
+
+	var (
+		wg      sync.WaitGroup
+		mu      sync.Mutex
+		done    int
+		tabs    = make([]*v1alpha1.DashboardTab, len(requests))
+		errs    = make([]error, len(requests))
+		indexes = make(chan int)
+	)
+
+	for range min(concurrency, len(requests)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				request := &requests[i]
+				tabs[i], errs[i] = t.FetchTabTests(ctx, &request.Summary, request.MinFailure, request.MinFlake)
+
+				if progress != nil {
+					mu.Lock()
+					done++
+					progress(done, len(requests))
+					mu.Unlock()
+				}
+			}
+		}()
+	}
+	for i := range requests {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+
+	var (
+		result    []*v1alpha1.DashboardTab
+		tabErrors []TabError
+	)
+	for i, tab := range tabs {
+		if errs[i] != nil {
+			summary := requests[i].Summary
+			tabErrors = append(tabErrors, TabError{
+				Dashboard: summary.DashboardName,
+				Tab:       summary.DashboardTab.TabName,
+				Err:       errs[i],
+			})
+			continue
+		}
+		result = append(result, tab)
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].BoardHash < result[j].BoardHash
+	})
+	sort.SliceStable(tabErrors, func(i, j int) bool {
+		return tabErrors[i].Dashboard+"#"+tabErrors[i].Tab < tabErrors[j].Dashboard+"#"+tabErrors[j].Tab
+	})
+	return result, tabErrors
+}
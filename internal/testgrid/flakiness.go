@@ -0,0 +1,87 @@
+package testgrid
+
+import (
+	"math"
+
+	"sigs.k8s.io/signalhound/api/v1alpha1"
+)
+
+// recencyDecay is the weight ratio between a run and the next more recent one.
+const recencyDecay = 0.9
+
+// Flakiness measures how flaky a test is over the visible window, all values
+// are percentages from 0 to 100.
+type Flakiness struct {
+	// FlipRate is the share of consecutive runs switching between pass and failure.
+	FlipRate int
+	// FailureRatio is the share of runs that failed or flaked.
+	FailureRatio int
+	// Score is the recency-weighted average of the failure and flip indicators
+	// of every run, so recent flips rank higher than old ones.
+	Score int
+}
+
+// ComputeFlakiness returns the flakiness of a test from its per-column
+// statuses, the first column being the most recent one. Columns without a
+// final result are ignored.
+func ComputeFlakiness(statuses []TestStatus) Flakiness {
+	var bad []bool
+	for _, status := range statuses {
+		switch {
+		case status.IsFailure(), status.IsFlaky():
+			bad = append(bad, true)
+		case status.IsPass():
+			bad = append(bad, false)
+		}
+	}
+	if len(bad) == 0 {
+		return Flakiness{}
+	}
+
+	var failures, flips int
+	var weighted, weights float64
+	weight := 1.0
+	for i, failed := range bad {
+		var indicator float64
+		if failed {
+			failures++
+			indicator++
+		}
+		// a run flips when its outcome differs from the previous, older run
+		if i+1 < len(bad) && failed != bad[i+1] {
+			flips++
+			indicator++
+		}
+		weighted += weight * indicator / 2
+		weights += weight
+		weight *= recencyDecay
+	}
+
+	flakiness := Flakiness{
+		FailureRatio: percent(float64(failures) / float64(len(bad))),
+		Score:        percent(weighted / weights),
+	}
+	if len(bad) > 1 {
+		flakiness.FlipRate = percent(float64(flips) / float64(len(bad)-1))
+	}
+	return flakiness
+}
+
+// FilterByFlakinessScore drops the tests of a flaky tab scoring below minScore,
+// failing tabs are left untouched. A minScore of 0 disables the filter.
+func FilterByFlakinessScore(tab *v1alpha1.DashboardTab, minScore int) {
+	if minScore <= 0 || tab.TabState != v1alpha1.FLAKY_STATUS {
+		return
+	}
+	tests := tab.TestRuns[:0]
+	for _, test := range tab.TestRuns {
+		if test.FlakinessScore >= minScore {
+			tests = append(tests, test)
+		}
+	}
+	tab.TestRuns = tests
+}
+
+func percent(ratio float64) int {
+	return int(math.Round(ratio * 100))
+}
@@ -0,0 +1,38 @@
+package testgrid
+
+import (
+	"sort"
+	"time"
+)
+
+// DefaultStaleMultiplier is how many run intervals a tab can go without a new
+// run before being considered stale.
+const DefaultStaleMultiplier = 3
+
+// now is replaced in tests.
+var now = time.Now
+
+// InferInterval returns the usual time between two runs of a job, as the
+// median gap between the column timestamps given in milliseconds.
+func InferInterval(timestamps []int64) time.Duration {
+	var gaps []int64
+	for i := 0; i+1 < len(timestamps); i++ {
+		if gap := timestamps[i] - timestamps[i+1]; gap > 0 {
+			gaps = append(gaps, gap)
+		}
+	}
+	if len(gaps) == 0 {
+		return 0
+	}
+	sort.Slice(gaps, func(i, j int) bool { return gaps[i] < gaps[j] })
+	return time.Duration(gaps[len(gaps)/2]) * time.Millisecond
+}
+
+// IsStale returns true when the last run is older than multiplier times the
+// usual interval. Tabs without a known interval are never stale.
+func IsStale(lastRun time.Time, interval time.Duration, multiplier int) bool {
+	if multiplier <= 0 || interval <= 0 || lastRun.IsZero() {
+		return false
+	}
+	return now().Sub(lastRun) > time.Duration(multiplier)*interval
+}
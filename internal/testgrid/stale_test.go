@@ -0,0 +1,87 @@
+package testgrid
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInferInterval(t *testing.T) {
+	tests := []struct {
+		name       string
+		timestamps []int64
+		expected   time.Duration
+	}{
+		{
+			name:       "median gap between runs",
+			timestamps: []int64{10_000_000, 7_000_000, 4_000_000, 3_000_000, 0},
+			expected:   50 * time.Minute,
+		},
+		{
+			name:       "single run has no interval",
+			timestamps: []int64{10_000},
+			expected:   0,
+		},
+		{
+			name:       "no runs",
+			timestamps: nil,
+			expected:   0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, InferInterval(tt.timestamps))
+		})
+	}
+}
+
+func TestIsStale(t *testing.T) {
+	current := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	defer func(previous func() time.Time) { now = previous }(now)
+	now = func() time.Time { return current }
+
+	tests := []struct {
+		name       string
+		lastRun    time.Time
+		interval   time.Duration
+		multiplier int
+		expected   bool
+	}{
+		{
+			name:       "recent run",
+			lastRun:    current.Add(-2 * time.Hour),
+			interval:   time.Hour,
+			multiplier: 3,
+			expected:   false,
+		},
+		{
+			name:       "missed three runs",
+			lastRun:    current.Add(-4 * time.Hour),
+			interval:   time.Hour,
+			multiplier: 3,
+			expected:   true,
+		},
+		{
+			name:       "disabled multiplier",
+			lastRun:    current.Add(-24 * time.Hour),
+			interval:   time.Hour,
+			multiplier: 0,
+			expected:   false,
+		},
+		{
+			name:       "unknown interval",
+			lastRun:    current.Add(-24 * time.Hour),
+			interval:   0,
+			multiplier: 3,
+			expected:   false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, IsStale(tt.lastRun, tt.interval, tt.multiplier))
+		})
+	}
+}
@@ -0,0 +1,135 @@
+package testgrid
+
+// TestStatus is the result of a test in a single TestGrid column, the values
+// match the TestStatus enum from the TestGrid API.
+type TestStatus int
+
+const (
+	StatusNoResult         TestStatus = 0
+	StatusPass             TestStatus = 1
+	StatusPassWithErrors   TestStatus = 2
+	StatusPassWithSkips    TestStatus = 3
+	StatusRunning          TestStatus = 4
+	StatusCategorizedAbort TestStatus = 5
+	StatusUnknown          TestStatus = 6
+	StatusCancel           TestStatus = 7
+	StatusBlocked          TestStatus = 8
+	StatusTimedOut         TestStatus = 9
+	StatusCategorizedFail  TestStatus = 10
+	StatusBuildFail        TestStatus = 11
+	StatusFail             TestStatus = 12
+	StatusFlaky            TestStatus = 13
+	StatusToolFail         TestStatus = 14
+	StatusBuildPassed      TestStatus = 15
+)
+
+// IsPass returns true for the passing statuses.
+func (s TestStatus) IsPass() bool {
+	switch s {
+	case StatusPass, StatusPassWithErrors, StatusPassWithSkips, StatusBuildPassed:
+		return true
+	}
+	return false
+}
+
+// IsFailure returns true for the failing statuses.
+func (s TestStatus) IsFailure() bool {
+	switch s {
+	case StatusTimedOut, StatusCategorizedFail, StatusBuildFail, StatusFail, StatusToolFail:
+		return true
+	}
+	return false
+}
+
+// IsFlaky returns true when the test failed and passed in the same run.
+func (s TestStatus) IsFlaky() bool {
+	return s == StatusFlaky
+}
+
+// HasResult returns false for columns without a final result.
+func (s TestStatus) HasResult() bool {
+	return s != StatusNoResult && s != StatusRunning
+}
+
+// DecodeStatuses expands the run-length encoded statuses into one status per
+// column, aligned with TestGroup.Timestamps and TestGroup.Changelists. When
+// the statuses are missing, a non-empty short text is taken as a failure.
+func (te *Test) DecodeStatuses(columns int) []TestStatus {
+	statuses := make([]TestStatus, 0, columns)
+	if len(te.Statuses) == 0 {
+		for i := 0; i < columns; i++ {
+			status := StatusNoResult
+			if i < len(te.ShortTexts) && te.ShortTexts[i] != "" {
+				status = StatusFail
+			}
+			statuses = append(statuses, status)
+		}
+		return statuses
+	}
+
+	for _, encoded := range te.Statuses {
+		for i := 0; i < encoded.Count && len(statuses) < columns; i++ {
+			statuses = append(statuses, TestStatus(encoded.Value))
+		}
+	}
+	for len(statuses) < columns {
+		statuses = append(statuses, StatusNoResult)
+	}
+	return statuses
+}
+
+// TestSummary is the outcome of a test over the visible columns.
+type TestSummary struct {
+	// Failures is the number of failing columns.
+	Failures int
+	// Flakes is the number of columns that failed and passed on retry.
+	Flakes int
+	// Streak is the number of consecutive failures in the most recent columns.
+	Streak int
+	// LastPass is the column index of the most recent pass, -1 if none.
+	LastPass int
+	// LatestFailure is the column index of the most recent failure, -1 if none.
+	LatestFailure int
+	// FirstFailure is the column index of the first failure of the current
+	// streak, or of the oldest failure when the test is not failing now, -1 if none.
+	FirstFailure int
+}
+
+// Summarize computes the counts and streaks of the per-column statuses, the
+// first column being the most recent one.
+func Summarize(statuses []TestStatus) TestSummary {
+	summary := TestSummary{LastPass: -1, LatestFailure: -1, FirstFailure: -1}
+	inStreak := true
+	for i, status := range statuses {
+		switch {
+		case status.IsFailure():
+			summary.Failures++
+			if summary.LatestFailure < 0 {
+				summary.LatestFailure = i
+			}
+			if inStreak {
+				summary.Streak++
+				summary.FirstFailure = i
+			} else if summary.Streak == 0 {
+				summary.FirstFailure = i
+			}
+		case status.IsFlaky():
+			summary.Flakes++
+			if summary.LatestFailure < 0 {
+				summary.LatestFailure = i
+			}
+			if summary.Streak == 0 {
+				summary.FirstFailure = i
+			}
+			inStreak = false
+		case status.IsPass():
+			if summary.LastPass < 0 {
+				summary.LastPass = i
+			}
+			inStreak = false
+		case status.HasResult():
+			inStreak = false
+		}
+	}
+	return summary
+}
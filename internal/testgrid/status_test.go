@@ -0,0 +1,153 @@
+package testgrid
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"sigs.k8s.io/signalhound/api/v1alpha1"
+	"sigs.k8s.io/signalhound/internal/links"
+)
+
+func TestDecodeStatuses(t *testing.T) {
+	tests := []struct {
+		name     string
+		test     Test
+		columns  int
+		expected []TestStatus
+	}{
+		{
+			name:     "run-length encoded statuses",
+			test:     Test{Statuses: []Statuses{{Count: 2, Value: 12}, {Count: 1, Value: 1}, {Count: 2, Value: 0}}},
+			columns:  5,
+			expected: []TestStatus{StatusFail, StatusFail, StatusPass, StatusNoResult, StatusNoResult},
+		},
+		{
+			name:     "padded and truncated to the columns",
+			test:     Test{Statuses: []Statuses{{Count: 1, Value: 13}, {Count: 5, Value: 1}}},
+			columns:  3,
+			expected: []TestStatus{StatusFlaky, StatusPass, StatusPass},
+		},
+		{
+			name:     "short texts fallback",
+			test:     Test{ShortTexts: []string{"", "F", ""}},
+			columns:  3,
+			expected: []TestStatus{StatusNoResult, StatusFail, StatusNoResult},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.test.DecodeStatuses(tt.columns))
+		})
+	}
+}
+
+func TestSummarize(t *testing.T) {
+	tests := []struct {
+		name     string
+		statuses []TestStatus
+		expected TestSummary
+	}{
+		{
+			name:     "current failing streak",
+			statuses: []TestStatus{StatusRunning, StatusFail, StatusNoResult, StatusFail, StatusPass, StatusFail},
+			expected: TestSummary{Failures: 3, Streak: 2, LastPass: 4, LatestFailure: 1, FirstFailure: 3},
+		},
+		{
+			name:     "flaky test currently passing",
+			statuses: []TestStatus{StatusPass, StatusFail, StatusPass, StatusFlaky, StatusPass},
+			expected: TestSummary{Failures: 1, Flakes: 1, LastPass: 0, LatestFailure: 1, FirstFailure: 3},
+		},
+		{
+			name:     "no failures",
+			statuses: []TestStatus{StatusPass, StatusNoResult},
+			expected: TestSummary{LastPass: 0, LatestFailure: -1, FirstFailure: -1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, Summarize(tt.statuses))
+		})
+	}
+}
+
+func TestFilterTabTestsStatuses(t *testing.T) {
+	testGroup := &TestGroup{
+		Query:       "kubernetes-ci-logs/logs/ci-kubernetes-e2e",
+		Timestamps:  []int64{5000, 4000, 3000, 2000, 1000},
+		Changelists: []string{"5", "4", "3", "2", "1"},
+		Tests: []Test{
+			{
+				Name:       "ci-kubernetes-e2e.Overall",
+				ShortTexts: []string{"", "F", "F", "", "F"},
+				Messages:   []string{"", "m", "m", "", "m"},
+				Statuses:   []Statuses{{Count: 1, Value: 4}, {Count: 2, Value: 12}, {Count: 1, Value: 1}, {Count: 1, Value: 12}},
+			},
+			{
+				Name:     "ci-kubernetes-e2e.Passing",
+				Statuses: []Statuses{{Count: 5, Value: 1}},
+			},
+		},
+	}
+
+	tests := filterTabTests(testGroup, v1alpha1.FAILING_STATUS, 2, 0, nil, links.Default())
+	assert.Len(t, tests, 1)
+	assert.Equal(t, 3, tests[0].FailureCount)
+	assert.Equal(t, 2, tests[0].CurrentStreak)
+	assert.Equal(t, int64(4000), tests[0].LatestTimestamp)
+	assert.Equal(t, int64(3000), tests[0].FirstTimestamp)
+	assert.Equal(t, int64(2000), tests[0].LastPassTimestamp)
+	assert.Contains(t, tests[0].ProwJobURL, "ci-kubernetes-e2e/4")
+
+	downstream := links.Config{ProwURL: "https://prow.example.com", TriageURL: "https://triage.example.com"}.WithDefaults()
+	tests = filterTabTests(testGroup, v1alpha1.FAILING_STATUS, 2, 0, nil, downstream)
+	assert.Equal(t, "https://prow.example.com/view/gs/kubernetes-ci-logs/logs/ci-kubernetes-e2e/4", tests[0].ProwJobURL)
+	assert.Equal(t, "https://triage.example.com/index.html?job=ci-kubernetes-e2e$&test=ci-kubernetes-e2e.Overall", tests[0].TriageURL)
+}
+
+func TestComputeFlakiness(t *testing.T) {
+	tests := []struct {
+		name     string
+		statuses []TestStatus
+		expected Flakiness
+	}{
+		{
+			name:     "always failing does not flip",
+			statuses: []TestStatus{StatusFail, StatusFail, StatusFail, StatusFail},
+			expected: Flakiness{FlipRate: 0, FailureRatio: 100, Score: 50},
+		},
+		{
+			name:     "alternating results flip every run",
+			statuses: []TestStatus{StatusFail, StatusPass, StatusFlaky, StatusPass},
+			expected: Flakiness{FlipRate: 100, FailureRatio: 50, Score: 66},
+		},
+		{
+			name:     "columns without results are ignored",
+			statuses: []TestStatus{StatusRunning, StatusPass, StatusNoResult, StatusPass},
+			expected: Flakiness{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, ComputeFlakiness(tt.statuses))
+		})
+	}
+
+	recent := ComputeFlakiness([]TestStatus{StatusFail, StatusPass, StatusPass, StatusPass, StatusPass})
+	old := ComputeFlakiness([]TestStatus{StatusPass, StatusPass, StatusPass, StatusPass, StatusFail})
+	assert.Greater(t, recent.Score, old.Score)
+}
+
+func TestFilterByFlakinessScore(t *testing.T) {
+	tests := []v1alpha1.TestResult{{TestName: "low", FlakinessScore: 10}, {TestName: "high", FlakinessScore: 60}}
+
+	flaky := &v1alpha1.DashboardTab{TabState: v1alpha1.FLAKY_STATUS, TestRuns: append([]v1alpha1.TestResult{}, tests...)}
+	FilterByFlakinessScore(flaky, 50)
+	assert.Equal(t, []v1alpha1.TestResult{tests[1]}, flaky.TestRuns)
+
+	failing := &v1alpha1.DashboardTab{TabState: v1alpha1.FAILING_STATUS, TestRuns: append([]v1alpha1.TestResult{}, tests...)}
+	FilterByFlakinessScore(failing, 50)
+	assert.Equal(t, tests, failing.TestRuns)
+}
@@ -1,19 +1,21 @@
 package testgrid
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
-	"io"
 	"net/http"
 	"strings"
 	"time"
 
 	"sigs.k8s.io/signalhound/api/v1alpha1"
+	"sigs.k8s.io/signalhound/internal/links"
 	"sigs.k8s.io/signalhound/internal/prow"
+	"sigs.k8s.io/signalhound/internal/sig"
 )
 
 var (
-	URL            = "https://testgrid.k8s.io"
+	URL            = links.DefaultTestGridURL
 	e2eSuitePrefix = `Kubernetes e2e suite.`
 	kubetestPrefix = `kubetest`
 	testRegex      = e2eSuitePrefix + `\[It\] \[(\w.*)\] (?<TEST>\w.*)`
@@ -79,27 +81,46 @@ func (te *Test) RenderStatuses(timestamps []int64) (string, int, int) {
 
 type TestGrid struct {
 	URL string
+
+	client     *http.Client
+	timeout    time.Duration
+	maxRetries int
+	backoff    time.Duration
+	userAgent  string
+	sigMapping *sig.Mapping
+	links      links.Config
+
+	staleMultiplier int
 }
 
-func NewTestGrid(url string) *TestGrid {
-	return &TestGrid{URL: url}
+func NewTestGrid(url string, opts ...Option) *TestGrid {
+	t := &TestGrid{
+		URL:        url,
+		client:     http.DefaultClient,
+		timeout:    defaultTimeout,
+		maxRetries: defaultMaxRetries,
+		backoff:    defaultBackoff,
+		userAgent:  defaultUserAgent,
+		links:      links.Config{TestGridURL: url}.WithDefaults(),
+
+		staleMultiplier: DefaultStaleMultiplier,
+	}
+	for _, opt := range opts {
+		opt(t)
+	}
+	return t
 }
 
 type DashboardMapper map[string]*v1alpha1.DashboardSummary
 
 // FetchTabSummary retrieves the summary data for a given dashboard from the TestGrid
-func (t *TestGrid) FetchTabSummary(dashboard string, filterStatus []string) (summary []v1alpha1.DashboardSummary, err error) {
-	var response *http.Response
+func (t *TestGrid) FetchTabSummary(ctx context.Context, dashboard string, filterStatus []string) (summary []v1alpha1.DashboardSummary, err error) {
 	url := fmt.Sprintf("%s/%s/summary", t.URL, cleanHTMLCharacters(dashboard))
 
 	// request summary data from TestGrid
-	if response, err = http.Get(url); err != nil {
-		return nil, fmt.Errorf("error fetching testgrid dashboard summary endpoint: %v", err)
-	}
-
 	var data []byte
-	if data, err = io.ReadAll(response.Body); err != nil {
-		return nil, fmt.Errorf("error parsing body response: %v", err)
+	if data, err = t.get(ctx, url); err != nil {
+		return nil, fmt.Errorf("error fetching testgrid dashboard summary endpoint: %w", err)
 	}
 
 	// unmarshal summary data into a struct
@@ -131,14 +152,9 @@ func filterDashboards(dashboardList DashboardMapper, url string, filterStatus []
 }
 
 // FetchTabTests returns the test group related to the tab of a dashboard
-func (t *TestGrid) FetchTabTests(summary *v1alpha1.DashboardSummary, minFailure, minFlake int) (tab *v1alpha1.DashboardTab, err error) {
-	var response *http.Response
-	if response, err = http.Get(summary.DashboardTab.TabURL); err != nil {
-		return tab, err
-	}
-
+func (t *TestGrid) FetchTabTests(ctx context.Context, summary *v1alpha1.DashboardSummary, minFailure, minFlake int) (tab *v1alpha1.DashboardTab, err error) {
 	var data []byte
-	if data, err = io.ReadAll(response.Body); err != nil {
+	if data, err = t.get(ctx, summary.DashboardTab.TabURL); err != nil {
 		return tab, err
 	}
 
@@ -150,25 +166,58 @@ func (t *TestGrid) FetchTabTests(summary *v1alpha1.DashboardSummary, minFailure,
 
 	aggregation := fmt.Sprintf("%s#%s", summary.DashboardName, summary.DashboardTab.TabName)
 	icon := ":large_purple_square:"
-	if summary.OverallState == v1alpha1.FAILING_STATUS {
+	switch summary.OverallState {
+	case v1alpha1.FAILING_STATUS:
 		icon = ":large_red_square:"
+	case v1alpha1.PASSING_STATUS:
+		icon = ":large_green_square:"
+	}
+
+	// a job that stopped running keeps its last state, compare the last run
+	// with the usual interval between runs to tell it apart.
+	interval := InferInterval(testGroup.Timestamps)
+	stale := IsStale(lastRunTime(testGroup.Timestamps, summary.LastRunTime), interval, t.staleMultiplier)
+	if stale {
+		icon += ":zzz:"
 	}
 
 	summary.DashboardTab.BoardHash = aggregation
-	summary.DashboardTab.TabURL = cleanHTMLCharacters(fmt.Sprintf("https://testgrid.k8s.io/%s&exclude-non-failed-tests=", aggregation))
-	summary.DashboardTab.TestRuns = filterTabTests(testGroup, summary.OverallState, minFailure, minFlake)
+	summary.DashboardTab.TabURL = cleanHTMLCharacters(t.links.Tab(aggregation))
+	summary.DashboardTab.TestRuns = filterTabTests(testGroup, summary.OverallState, minFailure, minFlake, t.sigMapping, t.links)
 	summary.DashboardTab.TabState = summary.OverallState
 	summary.DashboardTab.StateIcon = icon
+	summary.DashboardTab.Stale = stale
+	summary.DashboardTab.RunInterval = int64(interval / time.Second)
 
 	return summary.DashboardTab, nil
 }
 
-func filterTabTests(testGroup *TestGroup, state string, minFailure, minFlake int) (tests []v1alpha1.TestResult) {
+// lastRunTime returns the time of the most recent column, falling back to the
+// summary last run timestamp given in seconds or milliseconds.
+func lastRunTime(timestamps []int64, summaryLastRun int64) time.Time {
+	if len(timestamps) > 0 {
+		return time.UnixMilli(timestamps[0])
+	}
+	switch {
+	case summaryLastRun <= 0:
+		return time.Time{}
+	case summaryLastRun < 1e12:
+		return time.Unix(summaryLastRun, 0)
+	}
+	return time.UnixMilli(summaryLastRun)
+}
+
+func filterTabTests(testGroup *TestGroup, state string, minFailure, minFlake int, sigMapping *sig.Mapping, linkConfig links.Config) (tests []v1alpha1.TestResult) {
 	jobName := strings.Split(testGroup.Query, "/")
+	columns := len(testGroup.Timestamps)
+	categories := columnCategories(testGroup, columns)
 	for _, test := range testGroup.Tests {
-		errMessage, failures, firstFailure := test.RenderStatuses(testGroup.Timestamps)
+		errMessage, _, _ := test.RenderStatuses(testGroup.Timestamps)
+		statuses := test.DecodeStatuses(columns)
+		summary := Summarize(statuses)
+		failures, flakes := summary.Failures, summary.Failures+summary.Flakes
 		if ((failures >= minFailure || minFailure == 0) && state == v1alpha1.FAILING_STATUS) ||
-			((failures >= minFlake || minFlake == 0) && state == v1alpha1.FLAKY_STATUS) {
+			((flakes >= minFlake || minFlake == 0) && state == v1alpha1.FLAKY_STATUS) {
 			testName := test.Name
 			if strings.Contains(testName, e2eSuitePrefix) {
 				testName = prow.GetRegexParameter(testRegex, testName)["TEST"]
@@ -178,22 +227,51 @@ func filterTabTests(testGroup *TestGroup, state string, minFailure, minFlake int
 			}
 
 			var prowJobURL string
-			if firstFailure >= 0 && firstFailure < len(testGroup.Changelists) {
-				prowJobURL = cleanHTMLCharacters(fmt.Sprintf("https://prow.k8s.io/view/gs/%s/%s", testGroup.Query, testGroup.Changelists[firstFailure]))
+			if summary.LatestFailure >= 0 && summary.LatestFailure < len(testGroup.Changelists) {
+				prowJobURL = cleanHTMLCharacters(linkConfig.ProwJob(testGroup.Query, testGroup.Changelists[summary.LatestFailure]))
+			}
+			flakiness := ComputeFlakiness(statuses)
+			var lastPassCommit, firstFailureCommit string
+			if firstFailure := firstFailureSince(statuses, summary.LastPass); firstFailure >= 0 {
+				lastPassCommit = testGroup.ColumnCommit(summary.LastPass)
+				firstFailureCommit = testGroup.ColumnCommit(firstFailure)
 			}
 			tests = append(tests, v1alpha1.TestResult{
-				TestName:        test.Name,
-				LatestTimestamp: testGroup.Timestamps[0],
-				FirstTimestamp:  testGroup.Timestamps[len(testGroup.Timestamps)-1],
-				ProwJobURL:      prowJobURL,
-				TriageURL:       cleanHTMLCharacters(fmt.Sprintf("https://storage.googleapis.com/k8s-triage/index.html?job=%s$&test=%s", cleanHTMLCharacters(jobName[len(jobName)-1]), cleanHTMLCharacters(testName))),
-				ErrorMessage:    errMessage,
+				TestName:           test.Name,
+				LatestTimestamp:    columnTimestamp(testGroup.Timestamps, summary.LatestFailure, 0),
+				FirstTimestamp:     columnTimestamp(testGroup.Timestamps, summary.FirstFailure, columns-1),
+				LastPassTimestamp:  columnTimestamp(testGroup.Timestamps, summary.LastPass, -1),
+				FailureCount:       summary.Failures,
+				FlakeCount:         summary.Flakes,
+				CurrentStreak:      summary.Streak,
+				FlipRate:           flakiness.FlipRate,
+				FailureRatio:       flakiness.FailureRatio,
+				FlakinessScore:     flakiness.Score,
+				ProwJobURL:         prowJobURL,
+				TriageURL:          cleanHTMLCharacters(linkConfig.Triage(cleanHTMLCharacters(jobName[len(jobName)-1]), cleanHTMLCharacters(testName))),
+				ErrorMessage:       errMessage,
+				Sig:                sigMapping.Detect(jobName[len(jobName)-1], test.Name),
+				LastPassCommit:     lastPassCommit,
+				FirstFailureCommit: firstFailureCommit,
+				Category:           classifyRow(&test, summary.LatestFailure, categories),
 			})
 		}
 	}
 	return tests
 }
 
+// columnTimestamp returns the timestamp of the column, or of the fallback
+// column when index is negative. A negative fallback returns 0.
+func columnTimestamp(timestamps []int64, index, fallback int) int64 {
+	if index < 0 {
+		index = fallback
+	}
+	if index < 0 || index >= len(timestamps) {
+		return 0
+	}
+	return timestamps[index]
+}
+
 func hasStatus(boardStatus string, statuses []string) bool {
 	for _, status := range statuses {
 		if boardStatus == status {
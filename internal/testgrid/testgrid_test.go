@@ -1,6 +1,7 @@
 package testgrid
 
 import (
+	"context"
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
@@ -57,7 +58,7 @@ func Test_FetchSummary(t *testing.T) {
 			defer server.Close()
 
 			tg := NewTestGrid(server.URL)
-			summary, err := tg.FetchTabSummary(tt.dashboard, tt.filterStatus)
+			summary, err := tg.FetchTabSummary(context.Background(), tt.dashboard, tt.filterStatus)
 			assert.NoError(t, err)
 
 			if tt.match {
@@ -111,7 +112,7 @@ func Test_FetchTable(t *testing.T) {
 			}
 
 			tg := NewTestGrid(server.URL)
-			tabTest, err := tg.FetchTabTests(summary, 1, 1)
+			tabTest, err := tg.FetchTabTests(context.Background(), summary, 1, 1)
 			assert.NoError(t, err)
 
 			assert.NotEmpty(t, tabTest.StateIcon)
@@ -125,6 +126,57 @@ func Test_FetchTable(t *testing.T) {
 	}
 }
 
+func Test_FetchTabsTests(t *testing.T) {
+	testGroup := TestGroup{
+		Query:       "kubernetes-ci-logs/logs/ci-kubernetes-e2e",
+		Timestamps:  []int64{1758999193000},
+		Changelists: []string{"1972011571991285760"},
+		Tests: []Test{
+			{Name: "ci-kubernetes-e2e.Overall", ShortTexts: []string{"F"}, Messages: []string{"F"}},
+		},
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("tab") == "broken" {
+			w.Write([]byte("<html>")) // nolint
+			return
+		}
+		jsonData, _ := json.Marshal(testGroup)
+		w.Write(jsonData) // nolint
+	}))
+	defer server.Close()
+
+	var requests []TabRequest
+	for _, tab := range []string{"tab-c", "broken", "tab-a", "tab-b"} {
+		requests = append(requests, TabRequest{
+			Summary: v1alpha1.DashboardSummary{
+				OverallState:  v1alpha1.FAILING_STATUS,
+				DashboardName: dashboard,
+				DashboardTab: &v1alpha1.DashboardTab{
+					TabName: tab,
+					TabURL:  server.URL + "/table?tab=" + tab,
+				},
+			},
+		})
+	}
+
+	var progressCalls int
+	tg := NewTestGrid(server.URL)
+	tabs, tabErrors := tg.FetchTabsTests(context.Background(), requests, 2, func(done, total int) {
+		progressCalls++
+		assert.Equal(t, len(requests), total)
+	})
+
+	assert.Equal(t, len(requests), progressCalls)
+	assert.Len(t, tabs, 3)
+	for i, tab := range []string{"tab-a", "tab-b", "tab-c"} {
+		assert.Equal(t, dashboard+"#"+tab, tabs[i].BoardHash)
+		assert.Len(t, tabs[i].TestRuns, 1)
+	}
+	assert.Len(t, tabErrors, 1)
+	assert.Equal(t, "broken", tabErrors[0].Tab)
+	assert.Equal(t, dashboard, tabErrors[0].Dashboard)
+}
+
 func TestRenderStatuses(t *testing.T) {
 	message := "kubetest --timeout triggered"
 	tests := []struct {
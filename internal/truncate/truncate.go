@@ -0,0 +1,29 @@
+// Package truncate shortens strings to a byte length without splitting a
+// multi-byte UTF-8 character.
+package truncate
+
+import "unicode/utf8"
+
+// Head returns the longest prefix of s within max bytes ending on a rune boundary.
+func Head(s string, max int) string {
+	if len(s) <= max {
+		return s
+	}
+	end := max
+	for end > 0 && !utf8.RuneStart(s[end]) {
+		end--
+	}
+	return s[:end]
+}
+
+// Tail returns the longest suffix of s within max bytes starting on a rune boundary.
+func Tail(s string, max int) string {
+	if len(s) <= max {
+		return s
+	}
+	start := len(s) - max
+	for start < len(s) && !utf8.RuneStart(s[start]) {
+		start++
+	}
+	return s[start:]
+}
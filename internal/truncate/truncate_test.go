@@ -0,0 +1,52 @@
+package truncate
+
+import (
+	"testing"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHead(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		max      int
+		expected string
+	}{
+		{name: "short text is kept", text: "pods", max: 10, expected: "pods"},
+		{name: "ascii text is cut at max", text: "pods should", max: 4, expected: "pods"},
+		{name: "cut backs off to a rune start", text: "aéé", max: 4, expected: "aé"},
+		{name: "nothing fits", text: "é", max: 1, expected: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			head := Head(tt.text, tt.max)
+			assert.Equal(t, tt.expected, head)
+			assert.True(t, utf8.ValidString(head))
+		})
+	}
+}
+
+func TestTail(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		max      int
+		expected string
+	}{
+		{name: "short text is kept", text: "pods", max: 10, expected: "pods"},
+		{name: "ascii text is cut at max", text: "pods should", max: 6, expected: "should"},
+		{name: "cut advances to a rune start", text: "ééa", max: 4, expected: "éa"},
+		{name: "nothing fits", text: "é", max: 1, expected: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tail := Tail(tt.text, tt.max)
+			assert.Equal(t, tt.expected, tail)
+			assert.True(t, utf8.ValidString(tail))
+		})
+	}
+}
@@ -0,0 +1,98 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+	"sigs.k8s.io/signalhound/internal/prow"
+	"sigs.k8s.io/signalhound/internal/truncate"
+)
+
+const (
+	buildLogPageName = "BuildLog"
+
+	// maxExcerptLines and maxExcerptLength trim the build log inlined in the issue.
+	maxExcerptLines  = 30
+	maxExcerptLength = 4000
+)
+
+// showBuildLog scans the build log of the issue job in the background and
+// opens the failure excerpts in a scrollable page. Press i in the page to inline
+// an excerpt in the issue, esc to go back.
+func showBuildLog() {
+	issue := currentIssue
+	if issue == nil || issue.ProwURL == "" {
+		position.SetText("[red]no Prow job to fetch the build log from")
+		return
+	}
+	position.SetText("[yellow]Fetching the build log...")
+
+	go func() {
+		buildLog, err := newProw(issue.ProwURL).GetSpyGlassLens()
+		app.QueueUpdateDraw(func() {
+			if err != nil {
+				position.SetText(fmt.Sprintf("[red]error fetching build log: %v", err.Error()))
+				return
+			}
+			if len(buildLog.Excerpts) == 0 {
+				position.SetText("[yellow]No error found in the build log")
+				return
+			}
+			position.SetText(defaultPositionText)
+			renderBuildLogPage(issue, buildLog)
+		})
+	}()
+}
+
+// renderBuildLogPage shows the failure excerpts of the build log, the most relevant first.
+func renderBuildLogPage(issue *IssueTemplate, buildLog *prow.BuildLog) {
+	logPanel := tview.NewTextView().SetWrap(true).SetText(buildLog.Text())
+	setPanelDefaultStyle(logPanel.Box)
+	logPanel.SetTitle(formatTitle("Build Log - press i to inline in the issue"))
+
+	closePage := func() {
+		pages.RemovePage(buildLogPageName)
+		app.SetFocus(githubPanel)
+	}
+	logPanel.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEscape {
+			closePage()
+			return nil
+		}
+		if event.Key() == tcell.KeyRune && event.Rune() == 'i' {
+			issue.BuildLogExcerpt = trimExcerpt(buildLog.Excerpts[0].String(), false)
+			closePage()
+			if currentIssue == issue {
+				setGitHubPanel(issue, currentIssueState, currentIssueBoard, githubToken)
+				position.SetText("[blue]INLINED [yellow]BUILD LOG [blue]IN THE ISSUE!")
+			}
+			return nil
+		}
+		return event
+	})
+
+	grid := tview.NewGrid().SetRows(0, 1).
+		AddItem(logPanel, 0, 0, 1, 1, 0, 0, true).
+		AddItem(position, 1, 0, 1, 1, 0, 0, false)
+	pages.AddAndSwitchToPage(buildLogPageName, grid, true)
+	app.SetFocus(logPanel)
+}
+
+// trimExcerpt keeps the first lines of the text, or the last ones with tail.
+func trimExcerpt(text string, tail bool) string {
+	lines := strings.Split(strings.TrimSpace(text), "\n")
+	if len(lines) > maxExcerptLines {
+		if tail {
+			lines = lines[len(lines)-maxExcerptLines:]
+		} else {
+			lines = lines[:maxExcerptLines]
+		}
+	}
+	excerpt := strings.Join(lines, "\n")
+	if tail {
+		return truncate.Tail(excerpt, maxExcerptLength)
+	}
+	return truncate.Head(excerpt, maxExcerptLength)
+}
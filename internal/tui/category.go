@@ -0,0 +1,56 @@
+package tui
+
+import (
+	"slices"
+
+	"sigs.k8s.io/signalhound/api/v1alpha1"
+	"sigs.k8s.io/signalhound/internal/testgrid"
+)
+
+var (
+	allTabs        []*v1alpha1.DashboardTab // Store the unfiltered tabs, currentTabs holds the displayed ones
+	categoryFilter string                   // Category of the displayed tests, empty for all
+)
+
+// cycleCategoryFilter switches the displayed tests to the next category,
+// going back to all the tests after the last one.
+func cycleCategoryFilter() {
+	next := slices.Index(v1alpha1.CATEGORIES, categoryFilter) + 1
+	categoryFilter = ""
+	if next < len(v1alpha1.CATEGORIES) {
+		categoryFilter = v1alpha1.CATEGORIES[next]
+	}
+	tabsPanel.SetTitle(formatTitle(tabsPanelTitle()))
+	updateTabsPanel(allTabs)
+	app.SetFocus(tabsPanel)
+}
+
+// filterTabsByCategory returns copies of the tabs holding only the tests of
+// the category filter, tabs left without tests are dropped unless stale.
+func filterTabsByCategory(tabs []*v1alpha1.DashboardTab) []*v1alpha1.DashboardTab {
+	if categoryFilter == "" {
+		return tabs
+	}
+	var filtered []*v1alpha1.DashboardTab
+	for _, tab := range tabs {
+		filteredTab := *tab
+		filteredTab.TestRuns = slices.Clone(tab.TestRuns)
+		testgrid.FilterByCategory(&filteredTab, []string{categoryFilter})
+		if len(filteredTab.TestRuns) > 0 || filteredTab.Stale {
+			filtered = append(filtered, &filteredTab)
+		}
+	}
+	return filtered
+}
+
+// tabsPanelTitle returns the first panel title for the current view and filter.
+func tabsPanelTitle() string {
+	title := "Board#Tabs"
+	if correlatedView {
+		title = "Correlated Tests"
+	}
+	if categoryFilter != "" {
+		title += " [" + categoryFilter + "]"
+	}
+	return title
+}
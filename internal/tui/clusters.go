@@ -0,0 +1,85 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+	"sigs.k8s.io/signalhound/internal/cluster"
+	"sigs.k8s.io/signalhound/internal/report"
+)
+
+const clustersPageName = "Clusters"
+
+var lastClusterYPress time.Time // Track "yy" clipboard shortcut in the clusters page
+
+// showClustersPage switches to the page grouping the current failures by
+// root cause, esc goes back to the main page.
+func showClustersPage() {
+	clusters := cluster.Build(currentTabs, cluster.DefaultThreshold)
+
+	clustersPanel := tview.NewList().ShowSecondaryText(false)
+	setPanelDefaultStyle(clustersPanel.Box)
+	clustersPanel.SetTitle(formatTitle("Failure Clusters"))
+	clustersPanel.SetSelectedBackgroundColor(tcell.ColorBlue)
+	clustersPanel.SetHighlightFullLine(true)
+	clustersPanel.SetMainTextStyle(tcell.StyleDefault)
+
+	detailsPanel := tview.NewTextView().SetWrap(true)
+	setPanelDefaultStyle(detailsPanel.Box)
+	detailsPanel.SetTitle(formatTitle("Cluster Details"))
+
+	for _, c := range clusters {
+		text := fmt.Sprintf("[%d tests, %d jobs] %s", len(c.Members), len(c.Jobs()), report.Title(c.Text))
+		clustersPanel.AddItem(tview.Escape(text), "", 0, nil)
+	}
+	clustersPanel.SetChangedFunc(func(i int, mainText string, secondaryText string, shortcut rune) {
+		detailsPanel.SetText(clusterDetails(&clusters[i])).ScrollToBeginning()
+	})
+	clustersPanel.SetSelectedFunc(func(i int, mainText string, secondaryText string, shortcut rune) {
+		app.SetFocus(detailsPanel)
+	})
+	if len(clusters) > 0 {
+		detailsPanel.SetText(clusterDetails(&clusters[0]))
+	}
+
+	closePage := func() {
+		pages.RemovePage(clustersPageName)
+		app.SetFocus(tabsPanel)
+	}
+	clustersPanel.SetDoneFunc(closePage)
+	detailsPanel.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEscape {
+			app.SetFocus(clustersPanel)
+			return nil
+		}
+		if isYankShortcut(event, &lastClusterYPress) {
+			position.SetText("[blue]COPIED [yellow]CLUSTER [blue]TO THE CLIPBOARD!")
+			if err := CopyToClipboard(detailsPanel.GetText(false)); err != nil {
+				position.SetText(fmt.Sprintf("[red]error: %v", err.Error()))
+			}
+			return nil
+		}
+		return event
+	})
+
+	grid := tview.NewGrid().SetRows(0, 1).SetColumns(0, 0).
+		AddItem(clustersPanel, 0, 0, 1, 1, 0, 0, true).
+		AddItem(detailsPanel, 0, 1, 1, 1, 0, 0, false).
+		AddItem(position, 1, 0, 1, 2, 0, 0, false)
+	pages.AddAndSwitchToPage(clustersPageName, grid, true)
+	app.SetFocus(clustersPanel)
+}
+
+// clusterDetails renders the cluster as Markdown, ready to be pasted in an issue.
+func clusterDetails(c *cluster.Cluster) string {
+	var out strings.Builder
+	fmt.Fprintf(&out, "### Failure cluster\n\n%d tests failing in %d jobs with the same reason.\n\n", len(c.Members), len(c.Jobs()))
+	fmt.Fprintf(&out, "```\n%s\n```\n\n### Which tests are failing?\n\n", c.Text)
+	for _, member := range c.Members {
+		fmt.Fprintf(&out, "* [%s](%s) on [%s](%s)\n", member.Test.TestName, member.Test.ProwJobURL, member.Tab.BoardHash, member.Tab.TabURL)
+	}
+	return out.String()
+}
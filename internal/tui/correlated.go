@@ -0,0 +1,173 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+	"golang.org/x/text/cases"
+	"golang.org/x/text/language"
+	"sigs.k8s.io/signalhound/api/v1alpha1"
+	"sigs.k8s.io/signalhound/internal/testgrid"
+)
+
+var (
+	correlatedView         bool                      // Whether the first panel lists correlated tests instead of tabs
+	currentCorrelated      []testgrid.CorrelatedTest // Store current correlated tests for refresh
+	selectedCorrelatedName string                    // Store selected correlated test for refresh preservation
+)
+
+// toggleCorrelatedView switches the first panel between the Board#Tabs and the
+// tests correlated across all tabs.
+func toggleCorrelatedView() {
+	correlatedView = !correlatedView
+	// the selection is kept per view, don't carry the index over
+	tabsPanel.Clear()
+	brokenPanel.Clear()
+	slackPanel.SetText("", false)
+	githubPanel.SetText("", false)
+	tabsPanel.SetTitle(formatTitle(tabsPanelTitle()))
+	if correlatedView {
+		brokenPanel.SetTitle(formatTitle("Affected Jobs"))
+	} else {
+		brokenPanel.SetTitle(formatTitle("Tests"))
+	}
+	updateTabsPanel(allTabs)
+	app.SetFocus(tabsPanel)
+}
+
+// updateCorrelatedPanel lists each failing test once with the number of
+// affected jobs, selecting it lists the jobs in the tests panel.
+func updateCorrelatedPanel(tabs []*v1alpha1.DashboardTab) {
+	if index := tabsPanel.GetCurrentItem(); tabsPanel.GetItemCount() > 0 && index < len(currentCorrelated) {
+		selectedCorrelatedName = currentCorrelated[index].Name
+	}
+
+	tabsPanel.Clear()
+	correlated := testgrid.CorrelateTests(tabs)
+	currentCorrelated = correlated
+	currentTabs = tabs
+	for i := range correlated {
+		test := &correlated[i]
+		icon := "🟣"
+		if test.State() == v1alpha1.FAILING_STATUS {
+			icon = "🔴"
+		}
+		text := fmt.Sprintf("[%s] %s (%d jobs)", icon, tview.Escape(test.Name), len(test.Jobs))
+		tabsPanel.AddItem(text, "", 0, func() {
+			selectedCorrelatedName = test.Name
+			updateAffectedJobsPanel(test)
+		})
+	}
+
+	for i := range correlated {
+		if correlated[i].Name == selectedCorrelatedName {
+			tabsPanel.SetCurrentItem(i)
+			break
+		}
+	}
+}
+
+// updateAffectedJobsPanel lists the jobs of a correlated test, the first item
+// renders the messages for all the jobs at once.
+func updateAffectedJobsPanel(test *testgrid.CorrelatedTest) {
+	brokenPanel.Clear()
+	brokenPanel.AddItem(fmt.Sprintf("All %d jobs", len(test.Jobs)), "", 0, nil)
+	for _, job := range test.Jobs {
+		brokenPanel.AddItem(tview.Escape(strings.ReplaceAll(job.Tab.BoardHash, "#", " - ")), "", 0, nil)
+	}
+	app.SetFocus(brokenPanel)
+	brokenPanel.SetCurrentItem(0)
+	brokenPanel.SetChangedFunc(func(i int, mainText string, secondaryText string, shortcut rune) {
+		position.SetText(defaultPositionText)
+	})
+	brokenPanel.SetSelectedFunc(func(i int, mainText string, secondaryText string, shortcut rune) {
+		if i == 0 {
+			updateCorrelatedSlackPanel(test)
+			updateCorrelatedGitHubPanel(test, githubToken)
+		} else {
+			job := test.Jobs[i-1]
+			updateSlackPanel(job.Tab, &job.Test)
+			updateGitHubPanel(job.Tab, &job.Test, githubToken)
+		}
+		app.SetFocus(slackPanel)
+	})
+}
+
+// updateCorrelatedSlackPanel writes a Slack message listing every affected job.
+func updateCorrelatedSlackPanel(test *testgrid.CorrelatedTest) {
+	state := test.State()
+	icon := ":large_purple_square:"
+	if state == v1alpha1.FAILING_STATUS {
+		icon = ":large_red_square:"
+	}
+	lines := []string{fmt.Sprintf("%s %s on %d jobs: `%s`", icon, cases.Title(language.English).String(state), len(test.Jobs), test.Name)}
+	if sig := correlatedSig(test); sig != "" {
+		lines[0] += fmt.Sprintf(", sig-%s", sig)
+	}
+	for _, job := range test.Jobs {
+		lines = append(lines, fmt.Sprintf("• [%s](%s): [Prow](%s), [Triage](%s), last failure on %s",
+			job.Tab.BoardHash, job.Tab.TabURL, job.Test.ProwJobURL, job.Test.TriageURL, timeClean(job.Test.LatestTimestamp)))
+	}
+	setSlackPanel(strings.Join(lines, "\n"))
+}
+
+// updateCorrelatedGitHubPanel writes a single issue covering every affected job.
+func updateCorrelatedGitHubPanel(test *testgrid.CorrelatedTest, token string) {
+	first, latest := test.Jobs[0], test.Jobs[0]
+	issue := &IssueTemplate{TestName: test.Name, Sig: correlatedSig(test), Category: test.Jobs[0].Test.Category}
+	for _, job := range test.Jobs {
+		issue.Jobs = append(issue.Jobs, newIssueJob(job.Tab, &job.Test))
+		if job.Test.FirstTimestamp < first.Test.FirstTimestamp {
+			first = job
+		}
+		if job.Test.LatestTimestamp > latest.Test.LatestTimestamp {
+			latest = job
+		}
+	}
+
+	// the latest failure gives the most relevant links and error message
+	splitBoard := strings.Split(latest.Tab.BoardHash, "#")
+	issue.BoardName, issue.TabName = splitBoard[0], splitBoard[1]
+	issue.TestGridURL = latest.Tab.TabURL
+	issue.TriageURL = latest.Test.TriageURL
+	issue.ProwURL = latest.Test.ProwJobURL
+	issue.ErrMessage = latest.Test.ErrorMessage
+	issue.FirstFailure = timeClean(first.Test.FirstTimestamp)
+	issue.LastFailure = timeClean(latest.Test.LatestTimestamp)
+	issue.test = &latest.Test
+	setSuspectCommits(issue, &latest.Test)
+	setJUnit(issue, latest.Test.JUnit)
+
+	setGitHubPanel(issue, test.State(), latest.Tab.BoardHash, token)
+}
+
+// correlatedSig returns the first SIG detected in the affected jobs.
+func correlatedSig(test *testgrid.CorrelatedTest) string {
+	for _, job := range test.Jobs {
+		if job.Test.Sig != "" {
+			return job.Test.Sig
+		}
+	}
+	return ""
+}
+
+// tabsPanelInputCapture binds the correlated view toggle, the failure
+// clusters page and the category filter on the first panel.
+func tabsPanelInputCapture(event *tcell.EventKey) *tcell.EventKey {
+	if event.Key() == tcell.KeyRune {
+		switch event.Rune() {
+		case 'c':
+			toggleCorrelatedView()
+			return nil
+		case 'e':
+			showClustersPage()
+			return nil
+		case 'f':
+			cycleCategoryFilter()
+			return nil
+		}
+	}
+	return event
+}
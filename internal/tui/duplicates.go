@@ -0,0 +1,301 @@
+package tui
+
+import (
+	"fmt"
+	"path"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+	"sigs.k8s.io/signalhound/api/v1alpha1"
+	"sigs.k8s.io/signalhound/internal/github"
+	"sigs.k8s.io/signalhound/internal/prow"
+)
+
+const (
+	duplicatesPageName = "Duplicates"
+	confirmPageName    = "Confirm"
+)
+
+// issueAction is what pressing ctrl-b or ctrl-n files once no duplicate is
+// found, or when picked anyway from the duplicates page.
+type issueAction struct {
+	// createText labels the entry creating anyway on the duplicates page.
+	createText string
+	// confirmText is the question asked before creating.
+	confirmText string
+	// convertDrafts offers to convert the drafts found instead, only when
+	// filing a repository issue.
+	convertDrafts bool
+	create        func()
+}
+
+// lookupDuplicates searches the issues and project items already tracking the
+// test in the background, and asks to create only when none is found. Otherwise,
+// the matches open in a page offering to comment on one of them instead, or to
+// create anyway with the createText entry.
+func lookupDuplicates(issue *IssueTemplate, tabState, boardHash, token string, action issueAction) {
+	position.SetText("[yellow]Searching for existing issues...")
+
+	go func() {
+		gh := newProjectManager(token)
+		matches, err := gh.FindDuplicates(options.Issues, duplicateTerms(issue)...)
+		app.QueueUpdateDraw(func() {
+			if err == nil && len(matches) == 0 {
+				position.SetText(defaultPositionText)
+				confirm(action.confirmText, action.create)
+				return
+			}
+			position.SetText(defaultPositionText)
+			if err != nil {
+				position.SetText(fmt.Sprintf("[red]error searching for existing issues: %v", err.Error()))
+			}
+			renderDuplicatesPage(issue, matches, tabState, token, action)
+		})
+	}()
+}
+
+// confirm asks the question in a dialog over the current page, and runs the
+// action only once confirmed. Esc or cancel returns to the GitHub panel.
+func confirm(text string, action func()) {
+	modal := tview.NewModal().
+		SetText(text).
+		AddButtons([]string{"Confirm", "Cancel"}).
+		SetDoneFunc(func(_ int, label string) {
+			pages.RemovePage(confirmPageName)
+			app.SetFocus(githubPanel)
+			if label == "Confirm" {
+				action()
+			}
+		})
+	pages.AddPage(confirmPageName, modal, true, true)
+	app.SetFocus(modal)
+}
+
+// duplicateTerms returns the test and job names searched in the existing issues,
+// the bare tab name would match any issue mentioning the job and is left out.
+// Without a job config index, the job name is read from the Prow URL.
+func duplicateTerms(issue *IssueTemplate) []string {
+	terms := []string{issue.TestName}
+	for _, job := range issue.Jobs {
+		switch {
+		case job.Job != nil:
+			terms = append(terms, job.Job.Name)
+		case job.ProwURL != "":
+			if jobPath, err := prow.JobPathFromURL(job.ProwURL); err == nil {
+				terms = append(terms, path.Base(jobPath))
+			}
+		}
+	}
+	return terms
+}
+
+// renderDuplicatesPage lists the matches with their project status, enter
+// comments on the selected issue or, when filing a repository issue, converts
+// the selected draft to an issue. The last entry creates anyway.
+func renderDuplicatesPage(issue *IssueTemplate, matches []github.Match, tabState, token string, action issueAction) {
+	title := "Existing issues - enter to comment on the issue"
+	if action.convertDrafts {
+		title += " or convert the draft"
+	}
+	list := tview.NewList()
+	setPanelDefaultStyle(list.Box)
+	list.SetTitle(formatTitle(title))
+	list.SetSelectedBackgroundColor(tcell.ColorBlue)
+	list.SetHighlightFullLine(true)
+	list.SetMainTextStyle(tcell.StyleDefault)
+
+	closePage := func() {
+		pages.RemovePage(duplicatesPageName)
+		app.SetFocus(githubPanel)
+	}
+	for _, match := range matches {
+		list.AddItem(tview.Escape(matchText(match)), matchDetails(match, action.convertDrafts), 0, func() {
+			switch {
+			case match.Draft && action.convertDrafts:
+				closePage()
+				confirm(fmt.Sprintf("Convert the draft %q to an issue of %s?", match.Title, issueRepository()), func() {
+					convertDraftIssue(issue, match, tabState, token)
+				})
+			case match.Draft:
+				position.SetText("[yellow]The draft is already on the project board, ctrl-n converts it to an issue")
+			default:
+				closePage()
+				commentIssue(issue, match, token)
+			}
+		})
+	}
+	list.AddItem(action.createText, "", 0, func() {
+		closePage()
+		confirm(action.confirmText, action.create)
+	})
+	list.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEscape {
+			closePage()
+			return nil
+		}
+		return event
+	})
+
+	grid := tview.NewGrid().SetRows(0, 1).
+		AddItem(list, 0, 0, 1, 1, 0, 0, true).
+		AddItem(position, 1, 0, 1, 1, 0, 0, false)
+	pages.AddAndSwitchToPage(duplicatesPageName, grid, true)
+	app.SetFocus(list)
+}
+
+// matchText prints the status, number and title of an issue or project item.
+func matchText(match github.Match) string {
+	var out strings.Builder
+	if match.Status != "" {
+		fmt.Fprintf(&out, "[%s] ", match.Status)
+	}
+	if match.Number > 0 {
+		fmt.Fprintf(&out, "#%d ", match.Number)
+	}
+	out.WriteString(match.Title)
+	return out.String()
+}
+
+// matchDetails prints the link of the issue, or where to find a draft.
+func matchDetails(match github.Match, convertDrafts bool) string {
+	switch {
+	case match.Draft && convertDrafts:
+		return "  draft on the project board, enter converts it to an issue"
+	case match.Draft:
+		return "  draft on the project board"
+	case match.Status == "":
+		return "  " + match.URL + ", not on the project board"
+	default:
+		return "  " + match.URL
+	}
+}
+
+// commentIssue comments on the existing issue with the latest failure of the
+// test in the background.
+func commentIssue(issue *IssueTemplate, match github.Match, token string) {
+	comment, err := renderTemplate(issue, "template/comment.tmpl")
+	if err != nil {
+		position.SetText(fmt.Sprintf("[red]error: %v", err.Error()))
+		return
+	}
+	position.SetText(fmt.Sprintf("[yellow]Commenting on issue #%d...", match.Number))
+
+	go func() {
+		gh := newProjectManager(token)
+		err := gh.AddComment(match.ID, strings.TrimRight(comment.String(), "\r\n"))
+		app.QueueUpdateDraw(func() {
+			if err != nil {
+				position.SetText(fmt.Sprintf("[red]error: %v", err.Error()))
+				return
+			}
+			position.SetText(fmt.Sprintf("[blue]Commented on [yellow]ISSUE #%d [blue]on GitHub!", match.Number))
+		})
+	}()
+}
+
+// createDraftIssue creates the draft issue in the project with the given board
+// in the background.
+func createDraftIssue(title, body, boardHash, token string) {
+	position.SetText("[yellow]Creating the draft issue...")
+
+	go func() {
+		gh := newProjectManager(token)
+		err := gh.CreateDraftIssue(title, body, boardHash)
+		app.QueueUpdateDraw(func() {
+			if err != nil {
+				position.SetText(fmt.Sprintf("[red]error: %v", err.Error()))
+				return
+			}
+			position.SetText("[blue]Created [yellow]DRAFT ISSUE [blue] on GitHub Project!")
+			flashGitHubPanel()
+		})
+	}()
+}
+
+// createRepositoryIssue files the issue in the repository with its kind and
+// sig labels in the background, and adds it to the project with the given board.
+func createRepositoryIssue(issue *IssueTemplate, title, body, tabState, boardHash, token string) {
+	opts := repositoryIssueOptions(issue, tabState)
+	position.SetText("[yellow]Creating the issue...")
+
+	go func() {
+		gh := newProjectManager(token)
+		created, err := gh.CreateIssue(title, body, boardHash, opts)
+		app.QueueUpdateDraw(func() {
+			if err != nil {
+				position.SetText(fmt.Sprintf("[red]error: %v", err.Error()))
+				return
+			}
+			position.SetText(fmt.Sprintf("[blue]Created [yellow]ISSUE #%d [blue]on GitHub!", created.Number))
+			flashGitHubPanel()
+		})
+	}()
+}
+
+// convertDraftIssue converts the draft to a repository issue with the kind and
+// sig labels of the test in the background, its project fields are left as triaged.
+func convertDraftIssue(issue *IssueTemplate, match github.Match, tabState, token string) {
+	opts := repositoryIssueOptions(issue, tabState)
+	position.SetText("[yellow]Converting the draft to an issue...")
+
+	go func() {
+		gh := newProjectManager(token)
+		converted, err := gh.ConvertDraftIssue(match.ItemID, opts)
+		app.QueueUpdateDraw(func() {
+			if err != nil {
+				position.SetText(fmt.Sprintf("[red]error: %v", err.Error()))
+				return
+			}
+			position.SetText(fmt.Sprintf("[blue]Converted [yellow]DRAFT [blue]to [yellow]ISSUE #%d [blue]on GitHub!", converted.Number))
+			flashGitHubPanel()
+		})
+	}()
+}
+
+// repositoryIssueOptions adds the labels of the issue template to the configured ones.
+func repositoryIssueOptions(issue *IssueTemplate, tabState string) github.IssueOptions {
+	opts := options.Issues
+	opts.Labels = append(slices.Clone(opts.Labels), issueLabels(issue, tabState)...)
+	return opts
+}
+
+// issueRepository returns the owner/name of the repository the issues are filed in.
+func issueRepository() string {
+	if options.Issues.Repository != "" {
+		return options.Issues.Repository
+	}
+	return options.Project.Repository
+}
+
+// issueLabels returns the kind and sig labels matching the slash commands of the templates.
+func issueLabels(issue *IssueTemplate, tabState string) []string {
+	switch {
+	case issue.Category == v1alpha1.INFRA_CATEGORY:
+		return []string{"kind/failing-test", "sig/testing", "sig/k8s-infra"}
+	case tabState == v1alpha1.FAILING_STATUS:
+		if issue.Sig != "" {
+			return []string{"kind/failing-test", "sig/" + issue.Sig}
+		}
+		return []string{"kind/failing-test"}
+	case issue.Sig != "":
+		return []string{"kind/flake", "sig/" + issue.Sig}
+	default:
+		return []string{"kind/flake"}
+	}
+}
+
+// flashGitHubPanel highlights the GitHub panel for a second once the issue is
+// filed, then focuses the tests.
+func flashGitHubPanel() {
+	setPanelFocusStyle(githubPanel.Box)
+	go func() {
+		time.Sleep(1 * time.Second)
+		app.QueueUpdateDraw(func() {
+			app.SetFocus(brokenPanel)
+			setPanelDefaultStyle(githubPanel.Box)
+		})
+	}()
+}
@@ -4,6 +4,10 @@ import (
 	"bytes"
 	"embed"
 	"text/template"
+
+	"sigs.k8s.io/signalhound/api/v1alpha1"
+	"sigs.k8s.io/signalhound/internal/github"
+	"sigs.k8s.io/signalhound/internal/jobconfig"
 )
 
 //go:embed template/*
@@ -20,11 +24,50 @@ type IssueTemplate struct {
 	ProwURL      string
 	ErrMessage   string
 	Sig          string
+	Category     string
+	// Jobs lists every tab affected by the test, at least the one of BoardName and TabName.
+	Jobs []IssueJob
+
+	// LastPassCommit and FirstFailureCommit bound the suspect commit range.
+	LastPassCommit     string
+	FirstFailureCommit string
+	CompareURL         string
+	// PullRequests are the pull requests merged in the suspect range, nil until fetched.
+	PullRequests []github.PullRequest
+	// PullRequestsTruncated is set when the range is too long to list all its pull requests.
+	PullRequestsTruncated bool
+
+	// BuildLogExcerpt is the most relevant failure block of the build log, inlined on request.
+	BuildLogExcerpt string
+	// JUnit is the failure read from the JUnit artifacts, with a trimmed system-out.
+	JUnit *v1alpha1.JUnitResult
+
+	// test is the run the issue is rendered from, the latest failure of a correlated test.
+	test *v1alpha1.TestResult
+}
+
+// IssueJob is a job affected by the test of the issue.
+type IssueJob struct {
+	BoardHash   string
+	TestGridURL string
+	ProwURL     string
+	// Job is the Prow job definition of the tab, nil without a job config index.
+	Job *jobconfig.Job
+}
+
+func newIssueJob(tab *v1alpha1.DashboardTab, test *v1alpha1.TestResult) IssueJob {
+	return IssueJob{
+		BoardHash:   tab.BoardHash,
+		TestGridURL: tab.TabURL,
+		ProwURL:     test.ProwJobURL,
+		Job:         options.Jobs.Lookup(tab.BoardHash, test.ProwJobURL),
+	}
 }
 
 func renderTemplate(issue *IssueTemplate, templateFile string) (output bytes.Buffer, err error) {
 	var tmpl *template.Template
-	tmpl, err = template.ParseFS(tmplFolder, templateFile)
+	// the first file names the template, the others hold the shared partials
+	tmpl, err = template.ParseFS(tmplFolder, templateFile, "template/jobs.tmpl")
 	if err != nil {
 		return output, err
 	}
@@ -0,0 +1,73 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+	"sigs.k8s.io/signalhound/internal/jobconfig"
+)
+
+const jobDetailsPageName = "JobDetails"
+
+// showJobDetails opens the Prow job definitions of the issue jobs, read from
+// the test-infra config checkout, esc goes back.
+func showJobDetails() {
+	issue := currentIssue
+	if issue == nil {
+		return
+	}
+	if options.Jobs == nil {
+		position.SetText("[red]no job config index, set --job-config-dir to a test-infra config checkout")
+		return
+	}
+
+	var details []string
+	for _, issueJob := range issue.Jobs {
+		details = append(details, jobDetails(issueJob.BoardHash, issueJob.Job))
+	}
+	detailsPanel := tview.NewTextView().SetWrap(true).SetText(strings.Join(details, "\n"))
+	setPanelDefaultStyle(detailsPanel.Box)
+	detailsPanel.SetTitle(formatTitle("Job Details"))
+	detailsPanel.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEscape {
+			pages.RemovePage(jobDetailsPageName)
+			app.SetFocus(githubPanel)
+			return nil
+		}
+		return event
+	})
+
+	grid := tview.NewGrid().SetRows(0, 1).
+		AddItem(detailsPanel, 0, 0, 1, 1, 0, 0, true).
+		AddItem(position, 1, 0, 1, 1, 0, 0, false)
+	pages.AddAndSwitchToPage(jobDetailsPageName, grid, true)
+	app.SetFocus(detailsPanel)
+}
+
+// jobDetails prints the annotations and settings of the job of a Board#Tab.
+func jobDetails(boardHash string, job *jobconfig.Job) string {
+	if job == nil {
+		return fmt.Sprintf("%s\n  no Prow job found in the job config\n", boardHash)
+	}
+	var out strings.Builder
+	fmt.Fprintf(&out, "%s\n", boardHash)
+	for _, field := range []struct{ name, value string }{
+		{"Job", fmt.Sprintf("%s (%s)", job.Name, job.Type)},
+		{"Description", job.Description},
+		{"Owners", strings.Join(job.Owners, ", ")},
+		{"Alert emails", strings.Join(job.AlertEmails, ", ")},
+		{"Interval", job.Interval},
+		{"Cron", job.Cron},
+		{"Timeout", job.Timeout},
+		{"Cluster", job.Cluster},
+		{"Dashboards", strings.Join(job.Dashboards, ", ")},
+		{"Config", job.File},
+	} {
+		if field.value != "" {
+			fmt.Fprintf(&out, "  %-13s %s\n", field.name+":", field.value)
+		}
+	}
+	return out.String()
+}
@@ -0,0 +1,110 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+	"sigs.k8s.io/signalhound/internal/prow"
+)
+
+const jobHistoryPageName = "JobHistory"
+
+// resultColors highlight the build results in the job history page.
+var resultColors = map[string]string{
+	prow.ResultSuccess: "green",
+	prow.ResultFailure: "red",
+	prow.ResultAborted: "yellow",
+	prow.ResultError:   "yellow",
+	prow.ResultPending: "blue",
+}
+
+// showJobHistory reads the latest builds of the issue job in the background
+// and opens their durations and results in a page, esc goes back.
+func showJobHistory() {
+	issue := currentIssue
+	if issue == nil || issue.ProwURL == "" {
+		position.SetText("[red]no Prow job to read the history from")
+		return
+	}
+	position.SetText("[yellow]Fetching the job history...")
+
+	go func() {
+		builds, err := newProw(issue.ProwURL).GetJobHistory(context.Background(), prow.DefaultJobHistoryBuilds)
+		app.QueueUpdateDraw(func() {
+			if err != nil {
+				position.SetText(fmt.Sprintf("[red]error fetching job history: %v", err.Error()))
+				return
+			}
+			position.SetText(defaultPositionText)
+			renderJobHistoryPage(issue, builds)
+		})
+	}()
+}
+
+func renderJobHistoryPage(issue *IssueTemplate, builds []prow.Build) {
+	historyPanel := tview.NewTextView().SetDynamicColors(true).SetText(jobHistoryDetails(builds))
+	setPanelDefaultStyle(historyPanel.Box)
+	historyPanel.SetTitle(formatTitle(fmt.Sprintf("Job History - %s#%s", issue.BoardName, issue.TabName)))
+	historyPanel.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEscape {
+			pages.RemovePage(jobHistoryPageName)
+			app.SetFocus(githubPanel)
+			return nil
+		}
+		return event
+	})
+
+	grid := tview.NewGrid().SetRows(0, 1).
+		AddItem(historyPanel, 0, 0, 1, 1, 0, 0, true).
+		AddItem(position, 1, 0, 1, 1, 0, 0, false)
+	pages.AddAndSwitchToPage(jobHistoryPageName, grid, true)
+	app.SetFocus(historyPanel)
+}
+
+// jobHistoryDetails summarizes the results and durations of the builds,
+// the newest first, followed by one line per build.
+func jobHistoryDetails(builds []prow.Build) string {
+	if len(builds) == 0 {
+		return "No builds found"
+	}
+
+	var (
+		out       strings.Builder
+		results   = map[string]int{}
+		durations []time.Duration
+	)
+	for _, build := range builds {
+		results[build.Result]++
+		if build.Result != prow.ResultPending {
+			durations = append(durations, build.Duration)
+		}
+	}
+	fmt.Fprintf(&out, "%d builds:", len(builds))
+	for _, result := range []string{prow.ResultSuccess, prow.ResultFailure, prow.ResultAborted, prow.ResultError, prow.ResultPending} {
+		if results[result] > 0 {
+			fmt.Fprintf(&out, " [%s]%d %s[-]", resultColors[result], results[result], strings.ToLower(result))
+		}
+	}
+	if len(durations) > 0 {
+		sorted := slices.Clone(durations)
+		slices.Sort(sorted)
+		fmt.Fprintf(&out, "\nduration: latest %s, median %s, max %s",
+			durations[0], sorted[len(sorted)/2], sorted[len(sorted)-1])
+	}
+
+	out.WriteString("\n\nBUILD                STARTED           DURATION   RESULT     COMMIT\n")
+	for _, build := range builds {
+		color, ok := resultColors[build.Result]
+		if !ok {
+			color = "white"
+		}
+		fmt.Fprintf(&out, "%-20s %-17s %-10s [%s]%-10s[-] %s\n",
+			build.ID, build.Started.Format("2006-01-02 15:04"), build.Duration, color, build.Result, build.Commit)
+	}
+	return out.String()
+}
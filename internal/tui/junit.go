@@ -0,0 +1,54 @@
+package tui
+
+import (
+	"fmt"
+
+	"sigs.k8s.io/signalhound/api/v1alpha1"
+	"sigs.k8s.io/signalhound/internal/prow"
+)
+
+// loadJUnit reads the failure of the rendered test from the JUnit artifacts of
+// its latest failing run in the background, attaches it to the test and renders
+// the issue again if it is still displayed.
+func loadJUnit() {
+	issue := currentIssue
+	if issue == nil || issue.test == nil || issue.test.ProwJobURL == "" {
+		position.SetText("[red]no Prow job to read the JUnit artifacts from")
+		return
+	}
+	test := issue.test
+	buildPath, err := prow.BuildPathFromURL(test.ProwJobURL)
+	if err != nil {
+		position.SetText(fmt.Sprintf("[red]error: %v", err.Error()))
+		return
+	}
+	position.SetText("[yellow]Fetching the JUnit artifacts...")
+
+	go func() {
+		result, err := newGCS().TestFailure(buildPath, test.TestName)
+		app.QueueUpdateDraw(func() {
+			if err != nil {
+				position.SetText(fmt.Sprintf("[red]error reading JUnit: %v", err.Error()))
+				return
+			}
+			test.JUnit = result
+			if currentIssue != issue {
+				return
+			}
+			setJUnit(issue, result)
+			setGitHubPanel(issue, currentIssueState, currentIssueBoard, githubToken)
+			position.SetText(fmt.Sprintf("[blue]JUNIT: [yellow]failed at %s after %s", result.Location, result.Duration.Duration))
+		})
+	}()
+}
+
+// setJUnit sets the JUnit failure of the issue, keeping the end of the system-out.
+func setJUnit(issue *IssueTemplate, result *v1alpha1.JUnitResult) {
+	if result == nil {
+		issue.JUnit = nil
+		return
+	}
+	junit := *result
+	junit.SystemOut = trimExcerpt(junit.SystemOut, true)
+	issue.JUnit = &junit
+}
@@ -2,9 +2,12 @@ package tui
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"net/http"
 	"os/exec"
 	"runtime"
+	"sort"
 	"strings"
 	"time"
 
@@ -14,6 +17,10 @@ import (
 	"golang.org/x/text/language"
 	"sigs.k8s.io/signalhound/api/v1alpha1"
 	"sigs.k8s.io/signalhound/internal/github"
+	"sigs.k8s.io/signalhound/internal/jobconfig"
+	"sigs.k8s.io/signalhound/internal/links"
+	"sigs.k8s.io/signalhound/internal/prow"
+	"sigs.k8s.io/signalhound/internal/testgrid"
 )
 
 const (
@@ -32,12 +39,16 @@ var (
 	position          = tview.NewTextView()
 	currentTabs       []*v1alpha1.DashboardTab // Store current tabs for refresh
 	githubToken       string                   // Store token for refresh
+	options           Options                  // Store the CI links, job index and GitHub settings
 	selectedBoardHash string                   // Store selected BoardHash for refresh preservation
 	selectedTestName  string                   // Store selected test name for refresh preservation
 	lastSlackYPress   time.Time                // Track "yy" clipboard shortcut in Slack panel
 	lastGitHubYPress  time.Time                // Track "yy" clipboard shortcut in GitHub panel
 	lastSlackGPress   time.Time                // Track "gg" go-to-top shortcut in Slack panel
 	lastGitHubGPress  time.Time                // Track "gg" go-to-top shortcut in GitHub panel
+	currentIssue      *IssueTemplate           // Store the issue rendered in the GitHub panel
+	currentIssueState string                   // Store the tab state of the rendered issue
+	currentIssueBoard string                   // Store the Board#Tab of the rendered issue
 )
 
 func isDoubleRuneShortcut(event *tcell.EventKey, lastPress *time.Time, runes ...rune) bool {
@@ -165,6 +176,12 @@ func updateTabsPanel(tabs []*v1alpha1.DashboardTab) {
 	if tabsPanel == nil {
 		return
 	}
+	allTabs = tabs
+	tabs = filterTabsByCategory(tabs)
+	if correlatedView {
+		updateCorrelatedPanel(tabs)
+		return
+	}
 
 	// Store current selection before clearing
 	if tabsPanel.GetItemCount() > 0 {
@@ -188,8 +205,14 @@ func updateTabsPanel(tabs []*v1alpha1.DashboardTab) {
 
 	for _, tab := range tabs {
 		icon := "🟣"
-		if tab.TabState == v1alpha1.FAILING_STATUS {
+		switch tab.TabState {
+		case v1alpha1.FAILING_STATUS:
 			icon = "🔴"
+		case v1alpha1.PASSING_STATUS:
+			icon = "🟢"
+		}
+		if tab.Stale {
+			icon += "💤"
 		}
 		tabText := fmt.Sprintf("[%s] %s", icon, strings.ReplaceAll(tab.BoardHash, "#", " - "))
 
@@ -201,6 +224,7 @@ func updateTabsPanel(tabs []*v1alpha1.DashboardTab) {
 				selectedTestName = "" // Clear test selection when tab changes
 
 				brokenPanel.Clear()
+				sortByFlakiness(tab.TestRuns)
 				for _, test := range tab.TestRuns {
 					brokenPanel.AddItem(tview.Escape(test.TestName), "", 0, nil)
 				}
@@ -217,9 +241,9 @@ func updateTabsPanel(tabs []*v1alpha1.DashboardTab) {
 				brokenPanel.SetSelectedFunc(func(i int, testName string, secondaryText string, shortcut rune) {
 					// Store the selected test name
 					selectedTestName = testName
-					var currentTest = tab.TestRuns[i]
-					updateSlackPanel(tab, &currentTest)
-					updateGitHubPanel(tab, &currentTest, githubToken)
+					var currentTest = &tab.TestRuns[i]
+					updateSlackPanel(tab, currentTest)
+					updateGitHubPanel(tab, currentTest, githubToken)
 					app.SetFocus(slackPanel)
 				})
 			}
@@ -260,11 +284,36 @@ func updateTabsPanel(tabs []*v1alpha1.DashboardTab) {
 	}
 }
 
+// Options configures the TUI.
+type Options struct {
+	// Token is the GitHub token creating the drafts and issues.
+	Token string
+	// Links are the base URLs of the Prow instance and artifacts.
+	Links links.Config
+	// Jobs are the Prow job definitions, nil when not configured.
+	Jobs *jobconfig.Index
+	// Project is the GitHub project and field mapping of the drafts.
+	Project github.ProjectConfig
+	// Issues are the repository, labels and milestone of the filed issues.
+	Issues github.IssueOptions
+	// HTTPClient reads the Prow job pages and artifacts, through the response cache when enabled.
+	HTTPClient *http.Client
+	// RefreshInterval reloads the tabs with Refresh periodically, disabled when 0.
+	RefreshInterval time.Duration
+	Refresh         func() ([]*v1alpha1.DashboardTab, error)
+}
+
 // RenderVisual loads the entire grid and componnents in the app.
 // this is a blocking functions.
-func RenderVisual(tabs []*v1alpha1.DashboardTab, token string, refreshInterval time.Duration, refreshFunc func() ([]*v1alpha1.DashboardTab, error)) error {
+func RenderVisual(tabs []*v1alpha1.DashboardTab, opts Options) error {
 	app = tview.NewApplication()
-	githubToken = token
+	githubToken = opts.Token
+	options = opts
+	options.Links = opts.Links.WithDefaults()
+	options.Project = opts.Project.WithDefaults()
+	if options.HTTPClient == nil {
+		options.HTTPClient = http.DefaultClient
+	}
 	currentTabs = tabs
 
 	// Render tab in the first row
@@ -273,7 +322,8 @@ func RenderVisual(tabs []*v1alpha1.DashboardTab, token string, refreshInterval t
 	tabsPanel.SetSelectedBackgroundColor(tcell.ColorBlue)
 	tabsPanel.SetHighlightFullLine(true)
 	tabsPanel.SetMainTextStyle(tcell.StyleDefault)
-	tabsPanel.SetTitle(formatTitle("Board#Tabs"))
+	tabsPanel.SetTitle(formatTitle(tabsPanelTitle()))
+	tabsPanel.SetInputCapture(tabsPanelInputCapture)
 
 	// Broken tests in the tab
 	brokenPanel.ShowSecondaryText(false).SetDoneFunc(func() { app.SetFocus(tabsPanel) })
@@ -312,14 +362,18 @@ func RenderVisual(tabs []*v1alpha1.DashboardTab, token string, refreshInterval t
 	updateTabsPanel(tabs)
 
 	// Set up periodic refresh if interval is configured and refresh function is provided
-	if refreshInterval > 0 && refreshFunc != nil {
+	if opts.RefreshInterval > 0 && opts.Refresh != nil {
 		go func() {
-			ticker := time.NewTicker(refreshInterval)
+			ticker := time.NewTicker(opts.RefreshInterval)
 			defer ticker.Stop()
 			for range ticker.C {
-				newTabs, err := refreshFunc()
+				newTabs, err := opts.Refresh()
 				if err != nil {
 					app.QueueUpdateDraw(func() {
+						if errors.Is(err, testgrid.ErrRateLimited) {
+							position.SetText("[yellow]TestGrid is rate limiting requests, keeping the previous data")
+							return
+						}
 						position.SetText(fmt.Sprintf("[red]Refresh error: %v", err))
 					})
 					continue
@@ -344,6 +398,21 @@ func RenderVisual(tabs []*v1alpha1.DashboardTab, token string, refreshInterval t
 	return app.SetRoot(pages, true).EnableMouse(true).Run()
 }
 
+// newProjectManager returns the GitHub client of the configured project.
+func newProjectManager(token string) github.ProjectManagerInterface {
+	return github.NewProjectManager(context.Background(), token, github.WithProject(options.Project))
+}
+
+// newProw returns the Prow client of a job page, reading the artifacts from the configured bucket.
+func newProw(prowJobURL string) prow.ProwInterface {
+	return prow.NewProw(prowJobURL, prow.WithHTTPClient(options.HTTPClient), prow.WithGCS(newGCS()))
+}
+
+// newGCS returns the client of the configured artifacts bucket.
+func newGCS() *prow.GCS {
+	return prow.NewGCS(prow.WithBucketURL(options.Links.ArtifactURL), prow.WithGCSHTTPClient(options.HTTPClient))
+}
+
 // updateSlackPanel writes down to left panel (Slack) content.
 func updateSlackPanel(tab *v1alpha1.DashboardTab, currentTest *v1alpha1.TestResult) {
 	// set the item string with current test content
@@ -352,7 +421,14 @@ func updateSlackPanel(tab *v1alpha1.DashboardTab, currentTest *v1alpha1.TestResu
 		currentTest.TestName, currentTest.ProwJobURL, currentTest.TriageURL, timeClean(currentTest.LatestTimestamp),
 	)
 	item = strings.TrimRight(item, "\r\n")
+	if currentTest.Sig != "" {
+		item += fmt.Sprintf(", sig-%s", currentTest.Sig)
+	}
+	setSlackPanel(item)
+}
 
+// setSlackPanel renders the Slack message in the read-only left panel.
+func setSlackPanel(item string) {
 	// set input capture, "yy" for clipboard copy, esc to cancel panel selection.
 	slackPanel.SetText(item, false)
 	slackPanel.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
@@ -380,6 +456,18 @@ func updateSlackPanel(tab *v1alpha1.DashboardTab, currentTest *v1alpha1.TestResu
 					moveTextAreaToTop(slackPanel)
 				}
 				return nil
+			case 'l':
+				showBuildLog()
+				return nil
+			case 'u':
+				loadJUnit()
+				return nil
+			case 'h':
+				showJobHistory()
+				return nil
+			case 'm':
+				showJobDetails()
+				return nil
 			default:
 				// Read-only panel: ignore direct text edits.
 				return nil
@@ -415,11 +503,60 @@ func updateGitHubPanel(tab *v1alpha1.DashboardTab, currentTest *v1alpha1.TestRes
 		ErrMessage:   currentTest.ErrorMessage,
 		FirstFailure: timeClean(currentTest.FirstTimestamp),
 		LastFailure:  timeClean(currentTest.LatestTimestamp),
+		Sig:          currentTest.Sig,
+		Category:     currentTest.Category,
+		Jobs:         []IssueJob{newIssueJob(tab, currentTest)},
+		test:         currentTest,
+	}
+	setSuspectCommits(issue, currentTest)
+	setJUnit(issue, currentTest.JUnit)
+	setGitHubPanel(issue, tab.TabState, tab.BoardHash, token)
+}
+
+// setSuspectCommits sets the commit range between the last pass and the first failure of the test.
+func setSuspectCommits(issue *IssueTemplate, test *v1alpha1.TestResult) {
+	issue.LastPassCommit = test.LastPassCommit
+	issue.FirstFailureCommit = test.FirstFailureCommit
+	issue.CompareURL = github.CompareURL(options.Project.Repository, test.LastPassCommit, test.FirstFailureCommit)
+}
+
+// loadPullRequests fetches the pull requests merged in the suspect range in
+// the background and renders the issue again if it is still displayed.
+func loadPullRequests(issue *IssueTemplate, tabState, boardHash, token string) {
+	go func() {
+		gh := newProjectManager(token)
+		pullRequests, truncated, err := gh.ListMergedPullRequests(issue.LastPassCommit, issue.FirstFailureCommit)
+		app.QueueUpdateDraw(func() {
+			if err != nil {
+				position.SetText(fmt.Sprintf("[red]error listing suspect pull requests: %v", err.Error()))
+				pullRequests = []github.PullRequest{}
+			}
+			if currentIssue != issue {
+				return
+			}
+			issue.PullRequests, issue.PullRequestsTruncated = pullRequests, truncated
+			if issue.PullRequests == nil {
+				issue.PullRequests = []github.PullRequest{}
+			}
+			setGitHubPanel(issue, tabState, boardHash, token)
+		})
+	}()
+}
+
+// setGitHubPanel renders the issue in the right panel and binds the draft
+// creation on the given board.
+func setGitHubPanel(issue *IssueTemplate, tabState, boardHash, token string) {
+	currentIssue, currentIssueState, currentIssueBoard = issue, tabState, boardHash
+	if issue.CompareURL != "" && issue.PullRequests == nil && token != "" {
+		loadPullRequests(issue, tabState, boardHash, token)
 	}
 
-	// pick the correct template by failure status
+	// pick the correct template by category and failure status
 	templateFile, prefixTitle := "template/flake.tmpl", "Flaking Test"
-	if tab.TabState == v1alpha1.FAILING_STATUS {
+	switch {
+	case issue.Category == v1alpha1.INFRA_CATEGORY:
+		templateFile, prefixTitle = "template/infra.tmpl", "Infra Failure"
+	case tabState == v1alpha1.FAILING_STATUS:
 		templateFile, prefixTitle = "template/failure.tmpl", "Failing Test"
 	}
 	template, err := renderTemplate(issue, templateFile)
@@ -428,11 +565,12 @@ func updateGitHubPanel(tab *v1alpha1.DashboardTab, currentTest *v1alpha1.TestRes
 		return
 	}
 	issueBody := strings.TrimRight(template.String(), "\r\n")
-	issueTitle := fmt.Sprintf("[%v] %v", prefixTitle, currentTest.TestName)
+	issueTitle := fmt.Sprintf("[%v] %v", prefixTitle, issue.TestName)
 	githubPanel.SetText(issueBody, false)
 
 	// set input capture, "yy" for clipboard copy, ctrl-b for
-	// automatic GitHub draft issue creation.
+	// automatic GitHub draft issue creation and ctrl-n for a repository
+	// issue, both after the duplicates lookup.
 	githubPanel.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
 		if event.Key() == tcell.KeyRune {
 			switch event.Rune() {
@@ -458,25 +596,42 @@ func updateGitHubPanel(tab *v1alpha1.DashboardTab, currentTest *v1alpha1.TestRes
 					moveTextAreaToTop(githubPanel)
 				}
 				return nil
+			case 'l':
+				showBuildLog()
+				return nil
+			case 'u':
+				loadJUnit()
+				return nil
+			case 'h':
+				showJobHistory()
+				return nil
+			case 'm':
+				showJobDetails()
+				return nil
 			default:
 				// Read-only panel: ignore direct text edits.
 				return nil
 			}
 		}
 		if event.Key() == tcell.KeyCtrlB {
-			gh := github.NewProjectManager(context.Background(), token)
-			if err := gh.CreateDraftIssue(issueTitle, issueBody, tab.BoardHash); err != nil {
-				position.SetText(fmt.Sprintf("[red]error: %v", err.Error()))
-				return nil
-			}
-			position.SetText("[blue]Created [yellow]DRAFT ISSUE [blue] on GitHub Project!")
-			setPanelFocusStyle(githubPanel.Box)
-			go func() {
-				app.QueueUpdateDraw(func() {
-					app.SetFocus(brokenPanel)
-					setPanelDefaultStyle(githubPanel.Box)
-				})
-			}()
+			lookupDuplicates(issue, tabState, boardHash, token, issueAction{
+				createText:  "Create the draft anyway",
+				confirmText: "Create the draft issue on the project board?",
+				create: func() {
+					createDraftIssue(issueTitle, issueBody, boardHash, token)
+				},
+			})
+			return nil
+		}
+		if event.Key() == tcell.KeyCtrlN {
+			lookupDuplicates(issue, tabState, boardHash, token, issueAction{
+				createText:    "Create the issue anyway",
+				confirmText:   fmt.Sprintf("File the issue in %s?", issueRepository()),
+				convertDrafts: true,
+				create: func() {
+					createRepositoryIssue(issue, issueTitle, issueBody, tabState, boardHash, token)
+				},
+			})
 			return nil
 		}
 		if event.Key() == tcell.KeyEscape {
@@ -499,6 +654,13 @@ func updateGitHubPanel(tab *v1alpha1.DashboardTab, currentTest *v1alpha1.TestRes
 	})
 }
 
+// sortByFlakiness orders the tests with the worst offenders first.
+func sortByFlakiness(tests []v1alpha1.TestResult) {
+	sort.SliceStable(tests, func(i, j int) bool {
+		return tests[i].FlakinessScore > tests[j].FlakinessScore
+	})
+}
+
 // timeClean returns the string representation of the timestamp.
 func timeClean(ts int64) string {
 	return time.Unix(ts/1000, 0).UTC().Format(time.RFC1123)